@@ -0,0 +1,122 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"io"
+	"math/big"
+	"reflect"
+
+	"sigs.k8s.io/yaml"
+)
+
+// JSONEqual reports whether the JSON documents a and b are equal once
+// decoded, regardless of whitespace, the order of object members and the
+// notation of numbers.  Documents which fail to decode are compared byte for
+// byte.
+func JSONEqual(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	va, err := decodeJSONData(a)
+	if err != nil {
+		return false
+	}
+	vb, err := decodeJSONData(b)
+	if err != nil {
+		return false
+	}
+	return equalJSON(va, vb)
+}
+
+// YAMLEqual reports whether the YAML documents a and b are equal once
+// decoded, like JSONEqual.  Documents which fail to decode are compared byte
+// for byte.
+func YAMLEqual(a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	ja, err := yaml.YAMLToJSON(a)
+	if err != nil {
+		return false
+	}
+	jb, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return false
+	}
+	return JSONEqual(ja, jb)
+}
+
+// TextEqual reports whether a and b have the same text encoding.  Values
+// which fail to encode are compared with reflect.DeepEqual.
+func TextEqual(a, b encoding.TextMarshaler) bool {
+	ta, errA := a.MarshalText()
+	tb, errB := b.MarshalText()
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return bytes.Equal(ta, tb)
+}
+
+// decodeJSONData returns the single JSON document data decoded into a generic
+// value, keeping numbers as written.
+func decodeJSONData(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, &json.SyntaxError{Offset: decoder.InputOffset()}
+	}
+	return result, nil
+}
+
+// equalJSON reports whether the generic JSON values a and b are equal,
+// comparing numbers by value.
+func equalJSON(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, found := b[name]
+			if !found || !equalJSON(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalJSON(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		ra, okA := new(big.Rat).SetString(string(a))
+		rb, okB := new(big.Rat).SetString(string(b))
+		if !okA || !okB {
+			return a == b
+		}
+		return ra.Cmp(rb) == 0
+	}
+	return a == b
+}
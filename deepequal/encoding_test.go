@@ -0,0 +1,85 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestJSONEqual(t *testing.T) {
+	cases := []struct {
+		a, b  string
+		equal bool
+	}{
+		{a: ``, b: ``, equal: true},
+		{a: `{"a":1,"b":2}`, b: ` { "b" : 2, "a" : 1 } `, equal: true},
+		{a: `{"a":1}`, b: `{"a":1,"b":null}`, equal: false},
+		{a: `[1,2]`, b: `[2,1]`, equal: false},
+		{a: `1`, b: `1.0`, equal: true},
+		{a: `1e3`, b: `1000`, equal: true},
+		{a: `12345678901234567890`, b: `12345678901234567891`, equal: false},
+		{a: `"1"`, b: `1`, equal: false},
+		{a: `{"a":`, b: `{"a":`, equal: true},
+		{a: `{"a":`, b: `{"a": `, equal: false},
+		{a: `1 1`, b: `1  1`, equal: false},
+		{a: `null`, b: ` null`, equal: true},
+	}
+	for _, c := range cases {
+		if got := JSONEqual([]byte(c.a), []byte(c.b)); got != c.equal {
+			t.Errorf("JSONEqual(%s, %s): expected %v, got %v", c.a, c.b, c.equal, got)
+		}
+		if got := JSONEqual([]byte(c.b), []byte(c.a)); got != c.equal {
+			t.Errorf("JSONEqual(%s, %s): expected %v, got %v", c.b, c.a, c.equal, got)
+		}
+	}
+}
+
+func TestYAMLEqual(t *testing.T) {
+	cases := []struct {
+		a, b  string
+		equal bool
+	}{
+		{a: "a: 1\nb: [x, y]\n", b: "b:\n- x\n- y\na: 1", equal: true},
+		{a: "a: 1\n", b: `{"a": 1}`, equal: true},
+		{a: "a: 1\n", b: "a: 2\n", equal: false},
+		{a: "a: [\n", b: "a: [\n", equal: true},
+		{a: "a: [\n", b: "a: [ \n", equal: false},
+	}
+	for _, c := range cases {
+		if got := YAMLEqual([]byte(c.a), []byte(c.b)); got != c.equal {
+			t.Errorf("YAMLEqual(%q, %q): expected %v, got %v", c.a, c.b, c.equal, got)
+		}
+	}
+}
+
+type text struct {
+	value string
+	err   error
+}
+
+func (t *text) MarshalText() ([]byte, error) {
+	return []byte(t.value), t.err
+}
+
+func TestTextEqual(t *testing.T) {
+	failure := errors.New("failure")
+	cases := []struct {
+		name  string
+		a, b  *text
+		equal bool
+	}{
+		{name: "equal", a: &text{value: "a"}, b: &text{value: "a"}, equal: true},
+		{name: "different", a: &text{value: "a"}, b: &text{value: "b"}, equal: false},
+		{name: "both failing, equal values", a: &text{value: "a", err: failure}, b: &text{value: "a", err: failure}, equal: true},
+		{name: "one failing", a: &text{value: "a", err: failure}, b: &text{value: "a"}, equal: false},
+	}
+	for _, c := range cases {
+		if got := TextEqual(c.a, c.b); got != c.equal {
+			t.Errorf("%s: expected %v, got %v", c.name, c.equal, got)
+		}
+	}
+}
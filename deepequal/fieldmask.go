@@ -0,0 +1,141 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// Package deepequal provides the runtime support used by the methods which
+// deepequal-gen generates.
+package deepequal
+
+import (
+	"strings"
+)
+
+// Naming selects the names which the paths of a FieldMask give to struct
+// fields.
+type Naming int
+
+const (
+	// JSONNames names fields like encoding/json does: by the name in their
+	// json struct tag, or by their Go name if the tag has none.  The fields of
+	// structs embedded without a json name belong to the enclosing struct,
+	// and fields left out of JSON cannot be named.
+	JSONNames Naming = iota
+	// GoNames names fields by their Go name, including embedded fields.
+	GoNames
+)
+
+// FieldMask selects the fields compared by the DeepEqualMasked methods which
+// deepequal-gen generates.  It holds dotted field paths, such as
+// "spec.replicas", naming either the only fields compared or the fields left
+// out of the comparison.  A path selects the named field and every value
+// nested in it, and applies to the elements of the slices, maps and pointers
+// it goes through.  The zero FieldMask selects every field.
+type FieldMask struct {
+	node    *maskNode // The paths below the masked value, nil if the mask selects all or none of it.
+	exclude bool      // Whether the paths name the fields left out of the comparison.
+	naming  Naming
+	none    bool // Whether nothing is selected, when node is nil.
+}
+
+// maskNode is a node in the tree of the paths of a FieldMask.
+type maskNode struct {
+	end      bool // Whether a path ends here, selecting the whole value.
+	children map[string]*maskNode
+}
+
+// Include returns a FieldMask selecting only the fields named by paths.  An
+// empty path selects every field.
+func Include(naming Naming, paths ...string) FieldMask {
+	return FieldMask{node: newMaskNode(paths), naming: naming}.resolve()
+}
+
+// Exclude returns a FieldMask selecting every field but the ones named by
+// paths.  An empty path excludes every field.
+func Exclude(naming Naming, paths ...string) FieldMask {
+	return FieldMask{node: newMaskNode(paths), exclude: true, naming: naming}.resolve()
+}
+
+// Path returns the path naming the nested fields named, such as the path
+// "spec.replicas" of the replicas field of the spec field.
+func Path(names ...string) string {
+	return strings.Join(names, ".")
+}
+
+func newMaskNode(paths []string) *maskNode {
+	root := &maskNode{}
+	for _, path := range paths {
+		node := root
+		if path != "" {
+			for _, name := range strings.Split(path, ".") {
+				child := node.children[name]
+				if child == nil {
+					if node.children == nil {
+						node.children = map[string]*maskNode{}
+					}
+					child = &maskNode{}
+					node.children[name] = child
+				}
+				node = child
+			}
+		}
+		node.end = true
+	}
+	return root
+}
+
+// resolve returns the mask selecting all or none of the masked value if the
+// paths of m do so.
+func (m FieldMask) resolve() FieldMask {
+	switch {
+	case m.node == nil:
+		return m
+	case m.node.end:
+		return FieldMask{none: m.exclude}
+	case len(m.node.children) == 0:
+		return FieldMask{none: !m.exclude}
+	}
+	return m
+}
+
+// All returns whether m selects every field of the masked value.
+func (m FieldMask) All() bool {
+	return m.node == nil && !m.none
+}
+
+// Selected returns whether m selects any field of the masked value.  Values
+// without any field selected are considered equal.
+func (m FieldMask) Selected() bool {
+	return !m.none
+}
+
+// Field returns the mask of the struct field with the given Go and JSON
+// names, relative to the struct masked by m.  An empty JSON name stands for a
+// field left out of JSON.
+func (m FieldMask) Field(name, jsonName string) FieldMask {
+	if m.node == nil {
+		return m
+	}
+	if m.naming == JSONNames {
+		name = jsonName
+	}
+	var child *maskNode
+	if name != "" {
+		child = m.node.children[name]
+	}
+	if child == nil {
+		return FieldMask{none: !m.exclude}
+	}
+	return FieldMask{node: child, exclude: m.exclude, naming: m.naming}.resolve()
+}
+
+// Embedded returns the mask of the embedded struct field with the given Go
+// name and no JSON name, relative to the struct masked by m.  With JSONNames
+// the fields of the embedded struct are named like those of the enclosing
+// struct.
+func (m FieldMask) Embedded(name string) FieldMask {
+	if m.naming == JSONNames {
+		return m
+	}
+	return m.Field(name, "")
+}
@@ -0,0 +1,68 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"testing"
+)
+
+func TestFieldMask(t *testing.T) {
+	cases := []struct {
+		name     string
+		mask     FieldMask
+		fields   [][2]string // Go and JSON names of the nested fields.
+		all      bool
+		selected bool
+	}{
+		{name: "zero", mask: FieldMask{}, fields: [][2]string{{"Spec", "spec"}}, all: true, selected: true},
+		{name: "include field", mask: Include(JSONNames, "spec.replicas"), fields: [][2]string{{"Spec", "spec"}, {"Replicas", "replicas"}}, all: true, selected: true},
+		{name: "include parent", mask: Include(JSONNames, "spec.replicas"), fields: [][2]string{{"Spec", "spec"}}, all: false, selected: true},
+		{name: "include sibling", mask: Include(JSONNames, "spec.replicas"), fields: [][2]string{{"Spec", "spec"}, {"Paused", "paused"}}, all: false, selected: false},
+		{name: "include nested", mask: Include(JSONNames, "spec"), fields: [][2]string{{"Spec", "spec"}, {"Paused", "paused"}}, all: true, selected: true},
+		{name: "include prefix", mask: Include(JSONNames, "spec.replicas", "spec"), fields: [][2]string{{"Spec", "spec"}, {"Paused", "paused"}}, all: true, selected: true},
+		{name: "include nothing", mask: Include(JSONNames), fields: nil, all: false, selected: false},
+		{name: "include everything", mask: Include(JSONNames, ""), fields: [][2]string{{"Spec", "spec"}}, all: true, selected: true},
+		{name: "include go name", mask: Include(GoNames, "Spec"), fields: [][2]string{{"Spec", "spec"}}, all: true, selected: true},
+		{name: "include json name with go names", mask: Include(GoNames, "spec"), fields: [][2]string{{"Spec", "spec"}}, all: false, selected: false},
+		{name: "include field without json name", mask: Include(JSONNames, "Spec"), fields: [][2]string{{"Spec", ""}}, all: false, selected: false},
+		{name: "exclude field", mask: Exclude(JSONNames, "spec.replicas"), fields: [][2]string{{"Spec", "spec"}, {"Replicas", "replicas"}}, all: false, selected: false},
+		{name: "exclude parent", mask: Exclude(JSONNames, "spec.replicas"), fields: [][2]string{{"Spec", "spec"}}, all: false, selected: true},
+		{name: "exclude sibling", mask: Exclude(JSONNames, "spec.replicas"), fields: [][2]string{{"Spec", "spec"}, {"Paused", "paused"}}, all: true, selected: true},
+		{name: "exclude nothing", mask: Exclude(JSONNames), fields: nil, all: true, selected: true},
+		{name: "exclude everything", mask: Exclude(JSONNames, ""), fields: nil, all: false, selected: false},
+		{name: "exclude field without json name", mask: Exclude(JSONNames, "Spec"), fields: [][2]string{{"Spec", ""}}, all: true, selected: true},
+		{name: "unselected stays unselected", mask: Include(JSONNames, "status"), fields: [][2]string{{"Spec", "spec"}, {"Replicas", "replicas"}}, all: false, selected: false},
+	}
+	for _, c := range cases {
+		mask := c.mask
+		for _, field := range c.fields {
+			mask = mask.Field(field[0], field[1])
+		}
+		if mask.All() != c.all || mask.Selected() != c.selected {
+			t.Errorf("%s: expected All() %t and Selected() %t, got %t and %t", c.name, c.all, c.selected, mask.All(), mask.Selected())
+		}
+	}
+}
+
+func TestFieldMaskEmbedded(t *testing.T) {
+	mask := Include(JSONNames, "name")
+	if m := mask.Embedded("ObjectMeta").Field("Name", "name"); !m.All() {
+		t.Errorf("expected the fields of embedded structs to be named like those of the enclosing struct")
+	}
+	mask = Include(GoNames, "ObjectMeta.Name")
+	if m := mask.Embedded("ObjectMeta").Field("Name", "name"); !m.All() {
+		t.Errorf("expected embedded structs to be named by their Go name")
+	}
+	if m := mask.Field("Name", "name"); m.Selected() {
+		t.Errorf("expected fields of embedded structs not to be named like those of the enclosing struct")
+	}
+}
+
+func TestPath(t *testing.T) {
+	if path := Path("spec", "template", "replicas"); path != "spec.template.replicas" {
+		t.Errorf("unexpected path %q", path)
+	}
+}
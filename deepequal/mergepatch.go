@@ -0,0 +1,167 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// emptyPatch is the merge patch leaving its target unchanged.
+var emptyPatch = []byte("{}")
+
+// MergePatch is an RFC 7386 JSON merge patch being built by the
+// DeepMergePatch methods which deepequal-gen generates, holding the JSON
+// encoding of the value set for each member of the patched object.
+type MergePatch map[string]json.RawMessage
+
+// Set sets the member name of the patched object to the JSON encoding of
+// value, replacing it as a whole.
+func (p MergePatch) Set(name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	p[name] = data
+	return nil
+}
+
+// SetQuoted sets the member name of the patched object to the JSON encoding
+// of value quoted as a JSON string, like the json struct tag option "string"
+// does.
+func (p MergePatch) SetQuoted(name string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return p.Set(name, string(data))
+}
+
+// Remove removes the member name from the patched object.
+func (p MergePatch) Remove(name string) {
+	p[name] = json.RawMessage("null")
+}
+
+// Merge sets the member name of the patched object to the merge patch of the
+// nested object, unless it leaves the nested object unchanged.
+func (p MergePatch) Merge(name string, patch []byte) {
+	if !bytes.Equal(patch, emptyPatch) {
+		p[name] = patch
+	}
+}
+
+// Inline adds the members of the merge patch of an embedded object, whose
+// members belong to the patched object, to p.
+func (p MergePatch) Inline(patch []byte) error {
+	members := MergePatch{}
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return err
+	}
+	for name, value := range members {
+		p[name] = value
+	}
+	return nil
+}
+
+// Diff sets the member name of the patched object to the merge patch turning
+// the JSON encoding of original into that of modified, unless they are equal.
+// Objects are patched member by member, and other values are replaced as a
+// whole.
+func (p MergePatch) Diff(name string, original, modified interface{}) error {
+	o, m, err := decodeBoth(original, modified)
+	if err != nil {
+		return err
+	}
+	patch, changed := diffJSON(o, m)
+	if !changed {
+		return nil
+	}
+	return p.Set(name, patch)
+}
+
+// DiffInline adds the merge patch turning the JSON encoding of the embedded
+// object original into that of modified, whose members belong to the patched
+// object, to p.
+func (p MergePatch) DiffInline(original, modified interface{}) error {
+	o, m, err := decodeBoth(original, modified)
+	if err != nil {
+		return err
+	}
+	if o == nil {
+		o = map[string]interface{}{}
+	}
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+	patch, changed := diffJSON(o, m)
+	if !changed {
+		return nil
+	}
+	if members, ok := patch.(map[string]interface{}); ok {
+		for name, value := range members {
+			if err := p.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Marshal returns the JSON encoding of p.
+func (p MergePatch) Marshal() ([]byte, error) {
+	return json.Marshal(map[string]json.RawMessage(p))
+}
+
+// decodeBoth returns the JSON encodings of original and modified decoded into
+// generic values.
+func decodeBoth(original, modified interface{}) (interface{}, interface{}, error) {
+	o, err := decodeJSON(original)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := decodeJSON(modified)
+	if err != nil {
+		return nil, nil, err
+	}
+	return o, m, nil
+}
+
+// decodeJSON returns the JSON encoding of value decoded into a generic value,
+// keeping numbers as written.
+func decodeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSONData(data)
+}
+
+// diffJSON returns the merge patch turning the generic JSON value original
+// into modified, and whether they differ.
+func diffJSON(original, modified interface{}) (interface{}, bool) {
+	o, originalObject := original.(map[string]interface{})
+	m, modifiedObject := modified.(map[string]interface{})
+	if !originalObject || !modifiedObject {
+		return modified, !reflect.DeepEqual(original, modified)
+	}
+
+	patch := map[string]interface{}{}
+	for name := range o {
+		if _, found := m[name]; !found {
+			patch[name] = nil
+		}
+	}
+	for name, value := range m {
+		originalValue, found := o[name]
+		if !found {
+			patch[name] = value
+		} else if valuePatch, changed := diffJSON(originalValue, value); changed {
+			patch[name] = valuePatch
+		}
+	}
+	return patch, len(patch) > 0
+}
@@ -0,0 +1,126 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	type meta struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels,omitempty"`
+	}
+	cases := []struct {
+		name     string
+		build    func(p MergePatch) error
+		expected string
+	}{
+		{
+			name:     "empty",
+			build:    func(p MergePatch) error { return nil },
+			expected: `{}`,
+		},
+		{
+			name: "set and remove",
+			build: func(p MergePatch) error {
+				p.Remove("b")
+				return p.Set("a", []int{1, 2})
+			},
+			expected: `{"a":[1,2],"b":null}`,
+		},
+		{
+			name:     "set quoted",
+			build:    func(p MergePatch) error { return p.SetQuoted("version", int64(12)) },
+			expected: `{"version":"12"}`,
+		},
+		{
+			name: "merge",
+			build: func(p MergePatch) error {
+				p.Merge("unchanged", []byte(`{}`))
+				p.Merge("spec", []byte(`{"replicas":3}`))
+				return nil
+			},
+			expected: `{"spec":{"replicas":3}}`,
+		},
+		{
+			name:     "inline",
+			build:    func(p MergePatch) error { return p.Inline([]byte(`{"name":"b","labels":null}`)) },
+			expected: `{"labels":null,"name":"b"}`,
+		},
+		{
+			name: "diff objects",
+			build: func(p MergePatch) error {
+				return p.Diff("labels", map[string]string{"a": "1", "b": "2", "c": "3"}, map[string]string{"a": "1", "b": "4", "d": "5"})
+			},
+			expected: `{"labels":{"b":"4","c":null,"d":"5"}}`,
+		},
+		{
+			name: "diff nested objects",
+			build: func(p MergePatch) error {
+				return p.Diff("extra", map[string]interface{}{"a": map[string]int{"x": 1, "y": 2}}, map[string]interface{}{"a": map[string]int{"x": 1, "y": 3}})
+			},
+			expected: `{"extra":{"a":{"y":3}}}`,
+		},
+		{
+			name: "diff equal",
+			build: func(p MergePatch) error {
+				return p.Diff("labels", map[string]string{"a": "1"}, map[string]string{"a": "1"})
+			},
+			expected: `{}`,
+		},
+		{
+			name:     "diff replaces arrays",
+			build:    func(p MergePatch) error { return p.Diff("tags", []string{"a", "b"}, []string{"a"}) },
+			expected: `{"tags":["a"]}`,
+		},
+		{
+			name:     "diff keeps large numbers",
+			build:    func(p MergePatch) error { return p.Diff("n", int64(1)<<62, int64(1)<<62+1) },
+			expected: `{"n":4611686018427387905}`,
+		},
+		{
+			name: "diff inline",
+			build: func(p MergePatch) error {
+				return p.DiffInline(meta{Name: "a", Labels: map[string]string{"a": "1"}}, meta{Name: "b"})
+			},
+			expected: `{"labels":null,"name":"b"}`,
+		},
+		{
+			name:     "diff inline equal",
+			build:    func(p MergePatch) error { return p.DiffInline(meta{Name: "a"}, meta{Name: "a"}) },
+			expected: `{}`,
+		},
+	}
+	for _, c := range cases {
+		p := MergePatch{}
+		if err := c.build(p); err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		data, err := p.Marshal()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if string(data) != c.expected {
+			t.Errorf("%s: expected %s, got %s", c.name, c.expected, data)
+		}
+	}
+}
+
+func TestMergePatchErrors(t *testing.T) {
+	p := MergePatch{}
+	if err := p.Set("a", func() {}); err == nil {
+		t.Errorf("expected an error setting an unencodable value")
+	}
+	if err := p.Diff("a", 1, make(chan int)); err == nil {
+		t.Errorf("expected an error diffing an unencodable value")
+	}
+	if err := p.Inline([]byte(`[1]`)); err == nil {
+		t.Errorf("expected an error inlining a patch which is not an object")
+	}
+}
@@ -0,0 +1,180 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+)
+
+// Nils selects how UnstructuredEqual compares nil values with empty maps and
+// slices.
+type Nils int
+
+const (
+	// NilDiffersFromEmpty compares nil values, maps and slices, which JSON
+	// encodes as null, as different from empty maps and slices.
+	NilDiffersFromEmpty Nils = iota
+	// NilEqualsEmpty compares nil values as equal to empty maps and slices,
+	// and nil maps and slices as equal to empty ones.
+	NilEqualsEmpty
+)
+
+// UnstructuredEqual reports whether the unstructured values a and b, such as
+// the trees encoding/json decodes into interface{} values, are deeply equal.
+// Maps with string keys and slices of interface{} values are compared element
+// by element, numbers of any numeric type and json.Number by value, and other
+// values with reflect.DeepEqual.
+func UnstructuredEqual(a, b interface{}, nils Nils) bool {
+	if nils == NilEqualsEmpty {
+		if ea, eb := emptiness(a), emptiness(b); ea != notEmpty && eb != notEmpty {
+			return ea == eb || ea == emptyNil || eb == emptyNil
+		}
+	}
+
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || (a == nil) != (b == nil) || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, found := b[key]
+			if !found || !UnstructuredEqual(value, other, nils) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || (a == nil) != (b == nil) || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !UnstructuredEqual(a[i], b[i], nils) {
+				return false
+			}
+		}
+		return true
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case bool:
+		b, ok := b.(bool)
+		return ok && a == b
+	}
+
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x.equal(y)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// emptyValue classifies the unstructured values compared as nil or empty.
+type emptyValue int
+
+const (
+	notEmpty emptyValue = iota
+	emptyNil
+	emptyMap
+	emptySlice
+)
+
+// emptiness returns whether v is nil, an empty map or an empty slice.
+func emptiness(v interface{}) emptyValue {
+	switch v := v.(type) {
+	case nil:
+		return emptyNil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return emptyMap
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return emptySlice
+		}
+	}
+	return notEmpty
+}
+
+// numberKind is the representation of a number.
+type numberKind int
+
+const (
+	signedNumber numberKind = iota
+	unsignedNumber
+	floatNumber
+)
+
+// number is a numeric unstructured value.
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// toNumber returns the number v holds, if it is numeric.
+func toNumber(v interface{}) (number, bool) {
+	switch v := v.(type) {
+	case int:
+		return number{kind: signedNumber, i: int64(v)}, true
+	case int8:
+		return number{kind: signedNumber, i: int64(v)}, true
+	case int16:
+		return number{kind: signedNumber, i: int64(v)}, true
+	case int32:
+		return number{kind: signedNumber, i: int64(v)}, true
+	case int64:
+		return number{kind: signedNumber, i: v}, true
+	case uint:
+		return number{kind: unsignedNumber, u: uint64(v)}, true
+	case uint8:
+		return number{kind: unsignedNumber, u: uint64(v)}, true
+	case uint16:
+		return number{kind: unsignedNumber, u: uint64(v)}, true
+	case uint32:
+		return number{kind: unsignedNumber, u: uint64(v)}, true
+	case uint64:
+		return number{kind: unsignedNumber, u: v}, true
+	case uintptr:
+		return number{kind: unsignedNumber, u: uint64(v)}, true
+	case float32:
+		return number{kind: floatNumber, f: float64(v)}, true
+	case float64:
+		return number{kind: floatNumber, f: v}, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return number{kind: signedNumber, i: i}, true
+		}
+		if f, err := v.Float64(); err == nil {
+			return number{kind: floatNumber, f: f}, true
+		}
+	}
+	return number{}, false
+}
+
+// equal reports whether x and y hold the same value.
+func (x number) equal(y number) bool {
+	if x.kind > y.kind {
+		x, y = y, x
+	}
+	switch {
+	case x.kind == y.kind:
+		return x.i == y.i && x.u == y.u && x.f == y.f
+	case x.kind == signedNumber && y.kind == unsignedNumber:
+		return x.i >= 0 && uint64(x.i) == y.u
+	case x.kind == signedNumber:
+		// float64(1<<63) is out of the range of int64.
+		return y.f >= math.MinInt64 && y.f < math.MaxInt64 && y.f == math.Trunc(y.f) && int64(y.f) == x.i
+	default:
+		return y.f >= 0 && y.f < math.MaxUint64 && y.f == math.Trunc(y.f) && uint64(y.f) == x.u
+	}
+}
@@ -0,0 +1,70 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package deepequal
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestUnstructuredEqual(t *testing.T) {
+	cases := []struct {
+		name   string
+		a, b   interface{}
+		strict bool
+		loose  bool
+	}{
+		{name: "nil", a: nil, b: nil, strict: true, loose: true},
+		{name: "strings", a: "a", b: "a", strict: true, loose: true},
+		{name: "different strings", a: "a", b: "b"},
+		{name: "booleans", a: true, b: true, strict: true, loose: true},
+		{name: "string and boolean", a: "true", b: true},
+		{name: "ints", a: 1, b: int64(1), strict: true, loose: true},
+		{name: "int and float", a: int64(3), b: float64(3), strict: true, loose: true},
+		{name: "int and fraction", a: int64(3), b: 3.5},
+		{name: "signed and unsigned", a: int8(7), b: uint(7), strict: true, loose: true},
+		{name: "negative and unsigned", a: -1, b: uint64(math.MaxUint64)},
+		{name: "large unsigned and float", a: uint64(1) << 63, b: float64(1 << 63), strict: true, loose: true},
+		{name: "int64 overflowing float", a: int64(math.MaxInt64), b: float64(1 << 63)},
+		{name: "precision", a: int64(1<<53 + 1), b: float64(1 << 53)},
+		{name: "json numbers", a: json.Number("10"), b: 10.0, strict: true, loose: true},
+		{name: "json fraction", a: json.Number("2.5"), b: float32(2.5), strict: true, loose: true},
+		{name: "invalid json number", a: json.Number("x"), b: json.Number("x"), strict: true, loose: true},
+		{name: "NaN", a: math.NaN(), b: math.NaN()},
+		{name: "number and string", a: 1, b: "1"},
+		{
+			name:   "maps",
+			a:      map[string]interface{}{"a": 1, "b": []interface{}{"x", 2.0}},
+			b:      map[string]interface{}{"b": []interface{}{"x", 2}, "a": 1.0},
+			strict: true, loose: true,
+		},
+		{name: "missing key", a: map[string]interface{}{"a": 1}, b: map[string]interface{}{"b": 1}},
+		{name: "slice order", a: []interface{}{1, 2}, b: []interface{}{2, 1}},
+		{name: "nil and empty map", a: nil, b: map[string]interface{}{}, loose: true},
+		{name: "nil and empty slice", a: []interface{}{}, b: nil, loose: true},
+		{name: "nil map and empty map", a: map[string]interface{}(nil), b: map[string]interface{}{}, loose: true},
+		{name: "nil slice and empty slice", a: []interface{}(nil), b: []interface{}{}, loose: true},
+		{name: "empty map and empty slice", a: map[string]interface{}{}, b: []interface{}{}},
+		{name: "nested nil", a: map[string]interface{}{"a": nil}, b: map[string]interface{}{"a": []interface{}{}}, loose: true},
+		{name: "other types", a: []string{"a"}, b: []string{"a"}, strict: true, loose: true},
+		{name: "other types differing", a: []string{"a"}, b: []interface{}{"a"}},
+	}
+	for _, c := range cases {
+		if got := UnstructuredEqual(c.a, c.b, NilDiffersFromEmpty); got != c.strict {
+			t.Errorf("%s: expected %v with NilDiffersFromEmpty, got %v", c.name, c.strict, got)
+		}
+		if got := UnstructuredEqual(c.b, c.a, NilDiffersFromEmpty); got != c.strict {
+			t.Errorf("%s: expected %v with NilDiffersFromEmpty in reverse, got %v", c.name, c.strict, got)
+		}
+		if got := UnstructuredEqual(c.a, c.b, NilEqualsEmpty); got != c.loose {
+			t.Errorf("%s: expected %v with NilEqualsEmpty, got %v", c.name, c.loose, got)
+		}
+		if got := UnstructuredEqual(c.b, c.a, NilEqualsEmpty); got != c.loose {
+			t.Errorf("%s: expected %v with NilEqualsEmpty in reverse, got %v", c.name, c.loose, got)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// InputCache records a content hash of the inputs of every generated package
+// so that packages whose inputs did not change since the last run can be
+// skipped.  The inputs of a package are its source files, the source files of
+// every package it transitively imports from within the bounding dirs, the
+// directories of the packages they import from outside the bounding dirs, and
+// the generator options.
+type InputCache struct {
+	path    string
+	Entries map[string]InputCacheEntry `json:"entries"`
+}
+
+// InputCacheEntry holds the hashes recorded for a single package.
+type InputCacheEntry struct {
+	// Input is the hash of the package inputs.
+	Input string `json:"input"`
+	// Output is the hash of the generated file, or empty if none was
+	// generated.
+	Output string `json:"output,omitempty"`
+}
+
+// LoadInputCache reads the cache file at path.  A missing file yields an
+// empty cache.
+func LoadInputCache(path string) (*InputCache, error) {
+	c := &InputCache{
+		path:    path,
+		Entries: map[string]InputCacheEntry{},
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if c.Entries == nil {
+		c.Entries = map[string]InputCacheEntry{}
+	}
+	return c, nil
+}
+
+// Save writes the cache back to the file it was loaded from.
+func (c *InputCache) Save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, append(data, '\n'), 0644)
+}
+
+// UpToDate returns whether the recorded hashes of pkg match its current
+// input hash and the current contents of its output file.
+func (c *InputCache) UpToDate(pkg, inputHash, outputFile string) bool {
+	entry, found := c.Entries[pkg]
+	if !found || entry.Input != inputHash {
+		return false
+	}
+	outputHash, err := hashOutput(outputFile)
+	if err != nil {
+		return false
+	}
+	return entry.Output == outputHash
+}
+
+// Record stores the hashes of pkg after it was generated into outputFile.
+func (c *InputCache) Record(pkg, inputHash, outputFile string) error {
+	outputHash, err := hashOutput(outputFile)
+	if err != nil {
+		return err
+	}
+	c.Entries[pkg] = InputCacheEntry{Input: inputHash, Output: outputHash}
+	return nil
+}
+
+// hashOutput returns the hash of a generated file, or an empty string if the
+// file does not exist because the package did not need generation.
+func hashOutput(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// InputHasher computes the input hashes of packages.  Hashes of the source
+// files of each package are computed once and shared between all packages
+// which depend on it.
+type InputHasher struct {
+	context      build.Context
+	boundingDirs []string
+	options      []byte
+	packages     map[string]*hashedPackage
+}
+
+type hashedPackage struct {
+	hash    []byte
+	imports []string
+}
+
+// NewInputHasher returns an InputHasher for the given bounding dirs.  Files
+// carrying the generatedBuildTag build tag are not considered inputs.  The
+// options are folded into every hash so that changing the generator or its
+// arguments invalidates the cache.
+func NewInputHasher(boundingDirs []string, generatedBuildTag string, options ...[]byte) *InputHasher {
+	context := build.Default
+	context.BuildTags = append(append([]string{}, context.BuildTags...), generatedBuildTag)
+
+	h := sha256.New()
+	for _, option := range options {
+		fmt.Fprintf(h, "%d:", len(option))
+		h.Write(option)
+	}
+
+	return &InputHasher{
+		context:      context,
+		boundingDirs: boundingDirs,
+		options:      h.Sum(nil),
+		packages:     map[string]*hashedPackage{},
+	}
+}
+
+// Hash returns the input hash of the packages with import paths pkgs.
+func (h *InputHasher) Hash(pkgs ...string) (string, error) {
+	closure := map[string]*hashedPackage{}
+	for _, pkg := range pkgs {
+		if err := h.collect(pkg, closure); err != nil {
+			return "", err
+		}
+	}
+
+	paths := make([]string, 0, len(closure))
+	for path := range closure {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	sum := sha256.New()
+	sum.Write(h.options)
+	for _, path := range paths {
+		fmt.Fprintf(sum, "%s:", path)
+		sum.Write(closure[path].hash)
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// collect adds pkg and every package it transitively imports from within
+// the bounding dirs to closure, and the location of the packages they import
+// from outside the bounding dirs.
+func (h *InputHasher) collect(pkg string, closure map[string]*hashedPackage) error {
+	if _, found := closure[pkg]; found {
+		return nil
+	}
+	hashed, err := h.hashPackage(pkg)
+	if err != nil {
+		return err
+	}
+	closure[pkg] = hashed
+	for _, imported := range hashed.imports {
+		if imported == "C" {
+			continue
+		}
+		if !isRootedUnder(imported, h.boundingDirs) {
+			if err := h.locate(imported, closure); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := h.collect(imported, closure); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// locate adds the location of pkg, imported from outside the bounding dirs,
+// to closure.  The generated code depends on the methods of the types of pkg,
+// but its source files are not hashed: the directory of a module dependency
+// changes with its version, while edits made in place go unnoticed.
+func (h *InputHasher) locate(pkg string, closure map[string]*hashedPackage) error {
+	if _, found := closure[pkg]; found {
+		return nil
+	}
+	hashed, found := h.packages[pkg]
+	if !found {
+		p, err := h.context.Import(pkg, ".", build.FindOnly)
+		if err != nil {
+			return fmt.Errorf("unable to locate package %q: %v", pkg, err)
+		}
+		sum := sha256.Sum256([]byte(p.Dir))
+		hashed = &hashedPackage{hash: sum[:]}
+		h.packages[pkg] = hashed
+	}
+	closure[pkg] = hashed
+	return nil
+}
+
+func (h *InputHasher) hashPackage(pkg string) (*hashedPackage, error) {
+	if hashed, found := h.packages[pkg]; found {
+		return hashed, nil
+	}
+
+	p, err := h.context.Import(pkg, ".", 0)
+	if err != nil {
+		if _, noGo := err.(*build.NoGoError); !noGo {
+			return nil, fmt.Errorf("unable to hash package %q: %v", pkg, err)
+		}
+	}
+
+	files := append(append([]string{}, p.GoFiles...), p.CgoFiles...)
+	sort.Strings(files)
+
+	sum := sha256.New()
+	for _, file := range files {
+		f, err := os.Open(filepath.Join(p.Dir, file))
+		if err != nil {
+			return nil, fmt.Errorf("unable to hash package %q: %v", pkg, err)
+		}
+		fmt.Fprintf(sum, "%s:", file)
+		_, err = io.Copy(sum, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to hash package %q: %v", pkg, err)
+		}
+	}
+
+	hashed := &hashedPackage{
+		hash:    sum.Sum(nil),
+		imports: p.Imports,
+	}
+	h.packages[pkg] = hashed
+	return hashed, nil
+}
@@ -0,0 +1,131 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_InputCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepequal-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	cachePath := filepath.Join(dir, "cache.json")
+	output := filepath.Join(dir, "zz_generated.go")
+	missing := filepath.Join(dir, "missing.go")
+	if err := ioutil.WriteFile(output, []byte("package foo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := LoadInputCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.UpToDate("foo", "hash", output) {
+		t.Errorf("expected an empty cache not to be up to date")
+	}
+	if err := cache.Record("foo", "hash", output); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Record("bar", "hash", missing); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = LoadInputCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		pkg    string
+		hash   string
+		output string
+		expect bool
+	}{
+		{pkg: "foo", hash: "hash", output: output, expect: true},
+		{pkg: "foo", hash: "other", output: output, expect: false},
+		{pkg: "foo", hash: "hash", output: missing, expect: false},
+		{pkg: "bar", hash: "hash", output: missing, expect: true},
+		{pkg: "bar", hash: "hash", output: output, expect: false},
+		{pkg: "baz", hash: "hash", output: missing, expect: false},
+	}
+
+	for i, tc := range testCases {
+		if r := cache.UpToDate(tc.pkg, tc.hash, tc.output); r != tc.expect {
+			t.Errorf("case[%d]: expected %t, got %t", i, tc.expect, r)
+		}
+	}
+
+	// Modifying the generated file invalidates the entry.
+	if err := ioutil.WriteFile(output, []byte("package foo\n\n// edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cache.UpToDate("foo", "hash", output) {
+		t.Errorf("expected an edited output not to be up to date")
+	}
+}
+
+func Test_InputHasher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepequal-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(path, content string) {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a/src/example.com/api/types.go", "package api\n\nimport \"example.com/lib\"\n\ntype T struct{ L lib.L }\n")
+	write("b/src/example.com/lib/lib.go", "package lib\n\ntype L struct{}\n")
+
+	defer os.Setenv("GO111MODULE", os.Getenv("GO111MODULE"))
+	os.Setenv("GO111MODULE", "off")
+	hash := func() string {
+		h := NewInputHasher([]string{"example.com/api"}, "ignore_autogenerated")
+		h.context.GOPATH = filepath.Join(dir, "a") + string(filepath.ListSeparator) + filepath.Join(dir, "b")
+		sum, err := h.Hash("example.com/api")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sum
+	}
+
+	original := hash()
+	if hash() != original {
+		t.Errorf("expected the same inputs to hash the same")
+	}
+
+	// Packages outside the bounding dirs are hashed by location only.
+	write("b/src/example.com/lib/lib.go", "package lib\n\ntype L struct{ X int }\n")
+	if hash() != original {
+		t.Errorf("expected the sources outside the bounding dirs not to be hashed")
+	}
+	write("a/src/example.com/lib/lib.go", "package lib\n\ntype L struct{}\n")
+	moved := hash()
+	if moved == original {
+		t.Errorf("expected a new location of an imported package to change the hash")
+	}
+
+	write("a/src/example.com/api/types.go", "package api\n\nimport \"example.com/lib\"\n\ntype T struct{ M lib.L }\n")
+	if hash() == moved {
+		t.Errorf("expected a source change within the bounding dirs to change the hash")
+	}
+}
@@ -0,0 +1,204 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// maxFieldSetMembers is the number of fields a generated field set can hold.
+const maxFieldSetMembers = 64
+
+// changedFieldsMethodName returns the name of the ChangedFields method of
+// type t.
+func changedFieldsMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "changedFields"
+	}
+	return "ChangedFields"
+}
+
+// fieldSetType returns the name of the generated set of the fields of type t.
+func fieldSetType(t *types.Type) string {
+	return t.Name.Name + "FieldSet"
+}
+
+// fieldSetConstant returns the name of the generated constant standing for
+// member m of type t in its field set.
+func fieldSetConstant(t *types.Type, m *types.Member) string {
+	return t.Name.Name + "Field" + strings.ToUpper(m.Name[:1]) + m.Name[1:]
+}
+
+// comparedMembers returns the members of struct type t compared by its
+// generated DeepEqual method.
+func comparedMembers(t *types.Type) []*types.Member {
+	ut := underlyingType(t)
+	var members []*types.Member
+	for i := range ut.Members {
+		m := &ut.Members[i]
+		if ignoresMember(ut, m) || isLockMember(m) {
+			continue
+		}
+		members = append(members, m)
+	}
+	return members
+}
+
+// changedFieldsTypes returns the types which get a field set and a
+// ChangedFields method generated: the generated structs which opted in with
+// the changed-fields tag, and the structs they nest which are generated by
+// this run, so that changes can be inspected at every level.  An error is
+// returned for every such type which cannot have them.
+func changedFieldsTypes(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	changed := &optIn{
+		tag: tagChangedFieldsTagName,
+		eligible: func(t *types.Type) bool {
+			return fieldwiseType(t) && underlyingType(t).Kind == types.Struct
+		},
+		requirement: "a struct type",
+		nested: func(t *types.Type) []*types.Type {
+			return nestedStructs(t, policy)
+		},
+	}
+	result, errs := changed.types(generated, policy)
+
+	byName := map[string]*types.Type{}
+	for _, t := range generated {
+		byName[t.Name.String()] = t
+	}
+	for _, name := range result.List() {
+		t := byName[name]
+		members := comparedMembers(t)
+		if len(members) > maxFieldSetMembers {
+			errs = append(errs, fmt.Errorf("type %v: %s supports at most %d fields, found %d", t, tagChangedFieldsTagName, maxFieldSetMembers, len(members)))
+		}
+		for _, m := range members {
+			if fieldSetConstant(t, m) == fieldSetType(t) {
+				errs = append(errs, fmt.Errorf("type %v: %s cannot name the constant of field %s, which collides with type %s", t, tagChangedFieldsTagName, m.Name, fieldSetType(t)))
+			}
+		}
+	}
+	return result, errs
+}
+
+// nestedStructs returns the types nested in type t which get a field set as
+// well when they are generated structs: the types returned by
+// nestedValueTypes, with the slices and maps generated by this run replaced by
+// the types nested in them in turn.
+func nestedStructs(t *types.Type, policy *comparisonPolicy) []*types.Type {
+	var result []*types.Type
+	seen := sets.NewString()
+
+	var walk func(t *types.Type)
+	walk = func(t *types.Type) {
+		for _, nt := range nestedValueTypes(t) {
+			if underlyingType(nt).Kind == types.Struct || !policy.generating.Has(nt.Name.String()) || !fieldwiseType(nt) {
+				result = append(result, nt)
+				continue
+			}
+			if !seen.Has(nt.Name.String()) {
+				seen.Insert(nt.Name.String())
+				walk(nt)
+			}
+		}
+	}
+	walk(t)
+	return result
+}
+
+// doChangedFields generates the field set of struct type t and its
+// ChangedFields method.
+func (g *genDeepEqual) doChangedFields(t *types.Type, sw *generator.SnippetWriter) {
+	members := comparedMembers(t)
+	args := argsFromType(t)
+	args["name"] = changedFieldsMethodName(t)
+	args["method"] = deepEqualMethodName(t)
+	args["set"] = fieldSetType(t)
+
+	sw.Do("// $.set$ is a set of fields of $.type|raw$.\n", args)
+	sw.Do("type $.set$ uint64\n\n", args)
+	if len(members) > 0 {
+		sw.Do("// Fields of $.type|raw$ in a $.set$.\n", args)
+		sw.Do("const (\n", nil)
+		for i, m := range members {
+			memberArgs := generator.Args{
+				"constant": fieldSetConstant(t, m),
+				"set":      fieldSetType(t),
+			}
+			if i == 0 {
+				sw.Do("$.constant$ $.set$ = 1 << iota\n", memberArgs)
+			} else {
+				sw.Do("$.constant$\n", memberArgs)
+			}
+		}
+		sw.Do(")\n\n", nil)
+	}
+
+	sw.Do("// Has returns whether s holds all the fields in fields.\n", nil)
+	sw.Do("func (s $.set$) Has(fields $.set$) bool {\n", args)
+	sw.Do("return s&fields == fields\n", nil)
+	sw.Do("}\n\n", nil)
+
+	sw.Do("// Names returns the names of the fields in s, in declaration order.\n", nil)
+	sw.Do("func (s $.set$) Names() []string {\n", args)
+	sw.Do("names := []string{}\n", nil)
+	for _, m := range members {
+		memberArgs := generator.Args{
+			"constant": fieldSetConstant(t, m),
+			"name":     fmt.Sprintf("%q", m.Name),
+		}
+		sw.Do("if s&$.constant$ != 0 {\n", memberArgs)
+		sw.Do("names = append(names, $.name$)\n", memberArgs)
+		sw.Do("}\n", nil)
+	}
+	sw.Do("return names\n", nil)
+	sw.Do("}\n\n", nil)
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, returning the fields of\n", args)
+	sw.Do("// the receiver which differ from those of other when compared like $.method$.\n", args)
+	sw.Do("// in and other must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$(other *$.type|raw$) $.set$ {\n", args)
+	sw.Do("var changed $.set$\n\n", args)
+
+	g.ordered = g.policy.ordering.Has(t.Name.String())
+	ignoreNilFieldsTag := extractIgnoreNilFieldsTypeTag(underlyingType(t))
+	for _, m := range members {
+		memberArgs := generator.Args{
+			"constant": fieldSetConstant(t, m),
+		}
+		g.doMemberDiffers(t, m, ignoreNilFieldsTag, sw)
+		sw.Do("changed |= $.constant$\n", memberArgs)
+		sw.Do("}\n", nil)
+	}
+	g.ordered = false
+
+	sw.Do("\nreturn changed\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+// doMemberDiffers generates the opening of an if statement whose body runs
+// when member m of struct type t differs between in and other, compared like
+// the DeepEqual method of t does.
+func (g *genDeepEqual) doMemberDiffers(t *types.Type, m *types.Member, ignoreNilFieldsTag *enabledTagValue, sw *generator.SnippetWriter) {
+	if underlyingType(m.Type).Kind == types.Builtin && fieldEncoding(m) == "" {
+		sw.Do("if in.$.$ != other.$.$ {\n", m.Name)
+		return
+	}
+	// Compare the field in a function, so that the comparison generated for
+	// DeepEqual can return false.
+	sw.Do("if !func() bool {\n", nil)
+	g.doMember(t, m, ignoreNilFieldsTag, "", sw)
+	sw.Do("return true\n", nil)
+	sw.Do("}() {\n", nil)
+}
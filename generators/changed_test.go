@@ -0,0 +1,108 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_changedFieldsTypes(t *testing.T) {
+	newStruct := func(name string, fields int, comments ...string) *types.Type {
+		s := &types.Type{
+			Name:         types.Name{Package: "example.com/api", Name: name},
+			Kind:         types.Struct,
+			CommentLines: comments,
+		}
+		for i := 0; i < fields; i++ {
+			s.Members = append(s.Members, types.Member{Name: "Field" + strings.Repeat("X", i), Type: types.String})
+		}
+		return s
+	}
+	port := newStruct("Port", 1)
+	ports := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Ports"},
+		Kind: types.Slice,
+		Elem: port,
+	}
+	selector := newStruct("Selector", 1)
+	selectors := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Selectors"},
+		Kind: types.Map,
+		Key:  types.String,
+		Elem: &types.Type{Kind: types.Pointer, Elem: selector},
+	}
+	root := newStruct("Root", 0, "+deepequal-gen:changed-fields=true")
+	root.Members = []types.Member{
+		{Name: "Ports", Type: ports},
+		{Name: "Selectors", Type: selectors},
+	}
+	colliding := newStruct("Colliding", 0, "+deepequal-gen:changed-fields=true")
+	colliding.Members = []types.Member{{Name: "Set", Type: types.String}}
+	list := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "List"},
+		Kind:         types.Slice,
+		Elem:         types.String,
+		CommentLines: []string{"+deepequal-gen:changed-fields=true"},
+	}
+
+	testCases := []struct {
+		name      string
+		generated []*types.Type
+		expect    []string
+		errs      []string
+	}{
+		{
+			name:      "structs nested in slices and maps",
+			generated: []*types.Type{port, ports, root, selector, selectors},
+			expect:    []string{"example.com/api.Port", "example.com/api.Root", "example.com/api.Selector"},
+		},
+		{
+			name:      "nested slices and maps not generated",
+			generated: []*types.Type{port, root, selector},
+			expect:    []string{"example.com/api.Root"},
+		},
+		{
+			name:      "64 fields",
+			generated: []*types.Type{newStruct("Wide", maxFieldSetMembers, "+deepequal-gen:changed-fields=true")},
+			expect:    []string{"example.com/api.Wide"},
+		},
+		{
+			name:      "more than 64 fields",
+			generated: []*types.Type{newStruct("Wide", maxFieldSetMembers+1, "+deepequal-gen:changed-fields=true")},
+			expect:    []string{"example.com/api.Wide"},
+			errs:      []string{"type example.com/api.Wide: deepequal-gen:changed-fields supports at most 64 fields, found 65"},
+		},
+		{
+			name:      "colliding constant",
+			generated: []*types.Type{colliding},
+			expect:    []string{"example.com/api.Colliding"},
+			errs:      []string{"type example.com/api.Colliding: deepequal-gen:changed-fields cannot name the constant of field Set, which collides with type CollidingFieldSet"},
+		},
+		{
+			name:      "not a struct",
+			generated: []*types.Type{list},
+			errs:      []string{"type example.com/api.List: deepequal-gen:changed-fields requires a struct type with a generated DeepEqual method"},
+		},
+	}
+
+	for _, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+		for _, t := range tc.generated {
+			policy.generating.Insert(t.Name.String())
+		}
+		changed, errs := changedFieldsTypes(tc.generated, policy)
+		if strings.Join(changed.List(), ",") != strings.Join(tc.expect, ",") {
+			t.Errorf("%s: expected changed fields types %v, got %v", tc.name, tc.expect, changed.List())
+		}
+		if got := strings.Join(errs2strings(errs), "\n"); got != strings.Join(tc.errs, "\n") {
+			t.Errorf("%s: expected errors %q, got %q", tc.name, tc.errs, got)
+		}
+	}
+}
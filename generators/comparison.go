@@ -0,0 +1,283 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+// Known values for the fallback used to compare types which are outside the
+// bounding dirs, have no DeepEqual method and are not comparable with ==.
+const (
+	// FallbackReflect compares such types with reflect.DeepEqual.  This is
+	// the default.
+	FallbackReflect = "reflect"
+	// FallbackError reports such types as errors.
+	FallbackError = "error"
+)
+
+// comparison is a strategy for comparing a nested value.
+type comparison int
+
+const (
+	// compareMethod calls the DeepEqual method defined for the type.
+	compareMethod comparison = iota
+	// compareGenerated calls the DeepEqual method generated for the type,
+	// which is within the bounding dirs.
+	compareGenerated
+	// compareOperator compares the values with ==.
+	compareOperator
+	// compareReflect compares the values with reflect.DeepEqual.
+	compareReflect
+	// compareAtomic compares the values returned by the Load method of a
+	// sync/atomic type.
+	compareAtomic
+	// compareUnstructured compares the elements of maps and slices of the
+	// empty interface type with deepequal.UnstructuredEqual.
+	compareUnstructured
+)
+
+func (c comparison) String() string {
+	switch c {
+	case compareMethod:
+		return "its DeepEqual method"
+	case compareGenerated:
+		return "a generated DeepEqual method"
+	case compareOperator:
+		return "=="
+	case compareReflect:
+		return "reflect.DeepEqual"
+	case compareAtomic:
+		return "its Load method"
+	case compareUnstructured:
+		return "deepequal.UnstructuredEqual"
+	}
+	return fmt.Sprintf("comparison(%d)", int(c))
+}
+
+// comparisonPolicy decides how nested values are compared, based on the
+// bounding dirs and the methods defined for their types.
+type comparisonPolicy struct {
+	boundingDirs      []string
+	fallback          string
+	inputs            sets.String // Packages whose generated files are being replaced.
+	generating        sets.String // Types whose DeepEqual method is generated by this run.
+	transitive        bool        // Whether every copyable in-bounds type is generated on demand.
+	generatedBuildTag string
+	generated         map[string]sets.String // Methods declared in the generated files of each package.
+	matching          sets.String            // Types whose DeepMatches method is generated by this run.
+	cycleSafe         sets.String            // Types whose DeepEqualVisited method is generated by this run.
+	ordering          sets.String            // Types whose DeepCompare method is generated by this run.
+	normalizing       sets.String            // Types whose DeepNormalize method is generated by this run.
+	masking           sets.String            // Types whose DeepEqualMasked method is generated by this run.
+	changedFields     sets.String            // Types whose ChangedFields method is generated by this run.
+	mergePatching     sets.String            // Types whose DeepMergePatch method is generated by this run.
+	zeroTesting       sets.String            // Types whose DeepIsZero method is generated by this run.
+}
+
+func newComparisonPolicy(boundingDirs []string, fallback string, inputs sets.String, generatedBuildTag string) *comparisonPolicy {
+	if fallback == "" {
+		fallback = FallbackReflect
+	}
+	return &comparisonPolicy{
+		boundingDirs:      boundingDirs,
+		fallback:          fallback,
+		inputs:            inputs,
+		generating:        sets.NewString(),
+		generatedBuildTag: generatedBuildTag,
+		generated:         map[string]sets.String{},
+		matching:          sets.NewString(),
+		cycleSafe:         sets.NewString(),
+		ordering:          sets.NewString(),
+		normalizing:       sets.NewString(),
+		masking:           sets.NewString(),
+		changedFields:     sets.NewString(),
+		mergePatching:     sets.NewString(),
+		zeroTesting:       sets.NewString(),
+	}
+}
+
+// hasDeepEqual returns whether type t has a DeepEqual method, either parsed
+// from the source or declared in a previously generated file of a package
+// which is not regenerated by this run.
+func (p *comparisonPolicy) hasDeepEqual(t *types.Type) bool {
+	if deepEqualMethodOrDie(t) != nil {
+		return true
+	}
+	return p.hasGeneratedMethod(t, deepEqualMethodName(t))
+}
+
+// hasGeneratedMethod returns whether the named method of type t is declared
+// in a previously generated file of a package which is not regenerated by
+// this run.
+func (p *comparisonPolicy) hasGeneratedMethod(t *types.Type, name string) bool {
+	if t.Name.Package == "" || p.inputs.Has(t.Name.Package) {
+		return false
+	}
+	if _, found := p.generated[t.Name.Package]; !found {
+		p.generated[t.Name.Package] = generatedMethods(t.Name.Package, p.generatedBuildTag)
+	}
+	return p.generated[t.Name.Package].Has(t.Name.Name + "." + name)
+}
+
+func (p *comparisonPolicy) copyableAndInBounds(t *types.Type) bool {
+	if !comparableType(t) {
+		return false
+	}
+	// Only packages within the restricted range can be processed.
+	if !isRootedUnder(t.Name.Package, p.boundingDirs) {
+		return false
+	}
+	return true
+}
+
+// choose returns the strategy used to compare nested values of type t.  Types
+// with a DeepEqual method use it, and types within the bounding dirs use a
+// generated one.  Other types are compared with == if possible, or with the
+// configured fallback if they are outside the bounding dirs.
+func (p *comparisonPolicy) choose(t *types.Type) (comparison, error) {
+	inBounds := t.Name.Package != "" && isRootedUnder(t.Name.Package, p.boundingDirs)
+	switch {
+	case isAtomicType(t):
+		return compareAtomic, nil
+	case underlyingType(t).Kind == types.Interface:
+		// Values of interface types have no static type to generate for.
+		if p.fallback == FallbackReflect {
+			return compareReflect, nil
+		}
+		return 0, fmt.Errorf("interface type %v can only be compared with reflect.DeepEqual, use --fallback=%s", t, FallbackReflect)
+	case p.hasDeepEqual(t):
+		return compareMethod, nil
+	case p.copyableAndInBounds(t) && (p.transitive || p.generating.Has(t.Name.String())):
+		return compareGenerated, nil
+	case IsComparable(underlyingType(t)):
+		return compareOperator, nil
+	case inBounds:
+		return 0, p.notGeneratedError(t)
+	case p.fallback == FallbackReflect:
+		return compareReflect, nil
+	case t.Name.Package == "":
+		return 0, fmt.Errorf("unnamed type %v cannot have a DeepEqual method, use a named type instead", t)
+	default:
+		return 0, fmt.Errorf("type %v is outside the bounding dirs and has no DeepEqual method, add %s to --bounding-dirs or define the method", t, t.Name.Package)
+	}
+}
+
+// chooseAt returns the strategy used to compare the nested values of type t at
+// path.  The elements of maps and slices, whose paths end with [*], of the
+// empty interface type are unstructured values compared with
+// deepequal.UnstructuredEqual.  Other values are compared as chosen by choose.
+func (p *comparisonPolicy) chooseAt(t *types.Type, path string) (comparison, error) {
+	if strings.HasSuffix(path, "[*]") && unstructuredType(t) {
+		return compareUnstructured, nil
+	}
+	return p.choose(t)
+}
+
+// notGeneratedError explains why no DeepEqual method is generated for type t
+// within the bounding dirs, and how to fix it.
+func (p *comparisonPolicy) notGeneratedError(t *types.Type) error {
+	ttag := extractEnabledTypeTag(t)
+	method := deepEqualMethodName(t)
+	switch {
+	case ttag != nil && ttag.value == "false":
+		return fmt.Errorf("type %v opted out with +%s=false, define its %s method or remove the tag", t, tagEnabledName, method)
+	case !comparableType(t):
+		return fmt.Errorf("type %v cannot have a %s method generated, define the method", t, method)
+	default:
+		return fmt.Errorf("type %v has no %s method, add +%s=true to it, +%s=%s to package %s, or run with --transitive", t, method, tagEnabledName, tagEnabledName, tagValuePackage, t.Name.Package)
+	}
+}
+
+// generatesComparison returns whether the generated code of type t compares
+// its nested values itself, rather than only providing profile methods next
+// to a DeepEqual method written by the author.
+func generatesComparison(t *types.Type) bool {
+	return deepEqualMethodOrDie(t) == nil || len(extractProfileTypeTags(t)) > 0
+}
+
+// optIn describes how the generated types opt in to an additional method
+// with a tag, set to true on the type or as a default of its package.
+type optIn struct {
+	// tag is the name of the tag.
+	tag string
+	// eligible returns whether the method can be generated for type t.
+	eligible func(t *types.Type) bool
+	// requirement describes the eligible types in errors.
+	requirement string
+	// nested returns the types nested in type t which get the method as well
+	// when they are generated by this run and eligible.
+	nested func(t *types.Type) []*types.Type
+}
+
+// types returns the generated types which opted in and are eligible, and the
+// types nested in them which get the method as well.  Package defaults only
+// apply to the eligible types, and an error is returned for every other type
+// setting the tag.
+func (o *optIn) types(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	result := sets.NewString()
+	var errs []error
+
+	var walk func(t *types.Type)
+	walk = func(t *types.Type) {
+		for _, nt := range o.nested(t) {
+			if result.Has(nt.Name.String()) || !policy.generating.Has(nt.Name.String()) || !o.eligible(nt) {
+				continue
+			}
+			result.Insert(nt.Name.String())
+			walk(nt)
+		}
+	}
+
+	extract := func(comments []string) *enabledTagValue {
+		return extractSingleValueTag(o.tag, comments)
+	}
+	for _, t := range generated {
+		tag := extractTypeOrPackageTag(t, extract)
+		if tag == nil || tag.value != "true" || result.Has(t.Name.String()) {
+			continue
+		}
+		if !o.eligible(t) {
+			if extract(typeComments(t)) != nil {
+				errs = append(errs, fmt.Errorf("type %v: %s requires %s with a generated %s method", t, o.tag, o.requirement, deepEqualMethodName(t)))
+			}
+			continue
+		}
+		result.Insert(t.Name.String())
+		walk(t)
+	}
+	return result, errs
+}
+
+// checkDelegates returns an error for every nested value of the generated
+// types which the generated code cannot compare, naming the path of the
+// value.
+func checkDelegates(generated []*types.Type, policy *comparisonPolicy) []error {
+	var errs []error
+	for _, t := range generated {
+		if !generatesComparison(t) {
+			continue
+		}
+		for _, d := range delegates(t) {
+			if _, err := policy.chooseAt(d.t, d.path); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", delegatePath(t.Name.String(), d.path), err))
+			}
+		}
+	}
+	return errs
+}
+
+// delegatePath returns the path of a delegated value relative to parent.
+func delegatePath(parent, path string) string {
+	if strings.HasPrefix(path, "[") {
+		return parent + path
+	}
+	return parent + "." + path
+}
@@ -0,0 +1,171 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_checkDelegates(t *testing.T) {
+	slice := &types.Type{Kind: types.Slice, Elem: types.String}
+	newStruct := func(name string, comments ...string) *types.Type {
+		return &types.Type{
+			Name:         types.Name{Package: "example.com/api", Name: name},
+			Kind:         types.Struct,
+			CommentLines: comments,
+			Members:      []types.Member{{Name: "Values", Type: slice}},
+		}
+	}
+	generated := newStruct("Generated")
+	untagged := newStruct("Untagged")
+	private := newStruct("private")
+	optedOut := newStruct("OptedOut", "+deepequal-gen=false")
+	comparable := &types.Type{
+		Name:    types.Name{Package: "example.com/api", Name: "Comparable"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "Name", Type: types.String}},
+	}
+	external := &types.Type{
+		Name:    types.Name{Package: "example.com/external", Name: "External"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "Values", Type: slice}},
+	}
+	root := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Root"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Generated", Type: generated},
+			{Name: "Untagged", Type: &types.Type{Kind: types.Pointer, Elem: untagged}},
+			{Name: "Private", Type: private},
+			{Name: "OptedOut", Type: &types.Type{Kind: types.Slice, Elem: optedOut}},
+			{Name: "Comparable", Type: &types.Type{Kind: types.Pointer, Elem: comparable}},
+			{Name: "External", Type: external},
+			// Fields compared by their encoding are not delegated.
+			{Name: "Encoded", Type: external, Tags: `deepequal:"text"`},
+		},
+	}
+
+	testCases := []struct {
+		fallback string
+		errs     []string
+	}{
+		{
+			fallback: FallbackReflect,
+			errs: []string{
+				"example.com/api.Root.Untagged: type example.com/api.Untagged has no DeepEqual method, add +deepequal-gen=true to it",
+				"example.com/api.Root.Private: type example.com/api.private has no deepEqual method, add +deepequal-gen=true to it",
+				"example.com/api.Root.OptedOut[*]: type example.com/api.OptedOut opted out with +deepequal-gen=false",
+			},
+		},
+		{
+			fallback: FallbackError,
+			errs: []string{
+				"example.com/api.Root.Untagged: type example.com/api.Untagged has no DeepEqual method, add +deepequal-gen=true to it",
+				"example.com/api.Root.Private: type example.com/api.private has no deepEqual method, add +deepequal-gen=true to it",
+				"example.com/api.Root.OptedOut[*]: type example.com/api.OptedOut opted out with +deepequal-gen=false",
+				"example.com/api.Root.External: type example.com/external.External is outside the bounding dirs",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, tc.fallback, sets.NewString(), "ignore_autogenerated")
+		policy.generating.Insert(root.Name.String(), generated.Name.String())
+		errs := checkDelegates([]*types.Type{root}, policy)
+		if len(errs) != len(tc.errs) {
+			t.Errorf("case[%d]: expected %d errors, got %v", i, len(tc.errs), errs)
+			continue
+		}
+		for j := range errs {
+			if !strings.HasPrefix(errs[j].Error(), tc.errs[j]) {
+				t.Errorf("case[%d]: expected error starting with %q, got %q", i, tc.errs[j], errs[j])
+			}
+		}
+	}
+}
+
+func Test_optInTypes(t *testing.T) {
+	newType := func(name string, kind types.Kind, comments ...string) *types.Type {
+		return &types.Type{
+			Name:         types.Name{Package: "example.com/api", Name: name},
+			Kind:         kind,
+			CommentLines: comments,
+		}
+	}
+	port := newType("Port", types.Struct)
+	external := newType("External", types.Struct)
+	labels := &types.Type{
+		Name:       types.Name{Package: "example.com/api", Name: "Labels"},
+		Kind:       types.Alias,
+		Underlying: types.String,
+	}
+	root := newType("Root", types.Struct, "+deepequal-gen:compare=true")
+	root.Members = []types.Member{
+		{Name: "Port", Type: &types.Type{Kind: types.Pointer, Elem: port}},
+		{Name: "External", Type: external},
+		{Name: "Labels", Type: labels},
+	}
+	untagged := newType("Untagged", types.Struct)
+	optedOut := newType("OptedOut", types.Struct, "+deepequal-gen:compare=false")
+	name := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Name"},
+		Kind:         types.Alias,
+		Underlying:   types.String,
+		CommentLines: []string{"+deepequal-gen:compare=true"},
+	}
+
+	testCases := []struct {
+		name      string
+		defaults  []string
+		generated []*types.Type
+		expect    []string
+		errs      []string
+	}{
+		{
+			name:      "nested types generated by this run",
+			generated: []*types.Type{labels, port, root, untagged},
+			expect:    []string{"example.com/api.Port", "example.com/api.Root"},
+		},
+		{
+			name:      "package default",
+			defaults:  []string{"+deepequal-gen:compare=true"},
+			generated: []*types.Type{labels, optedOut, port, untagged},
+			expect:    []string{"example.com/api.Port", "example.com/api.Untagged"},
+		},
+		{
+			name:      "ineligible type",
+			generated: []*types.Type{name, untagged},
+			errs:      []string{"type example.com/api.Name: deepequal-gen:compare requires a struct, slice or map type with a generated DeepEqual method"},
+		},
+	}
+
+	installUniverse(types.Universe{"example.com/api": &types.Package{Path: "example.com/api"}})
+	defer installUniverse(nil)
+	for _, tc := range testCases {
+		headerComments["example.com/api"] = tc.defaults
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+		for _, t := range tc.generated {
+			policy.generating.Insert(t.Name.String())
+		}
+		o := &optIn{
+			tag:         tagCompareTagName,
+			eligible:    fieldwiseType,
+			requirement: "a struct, slice or map type",
+			nested:      nestedValueTypes,
+		}
+		result, errs := o.types(tc.generated, policy)
+		if strings.Join(result.List(), ",") != strings.Join(tc.expect, ",") {
+			t.Errorf("%s: expected types %v, got %v", tc.name, tc.expect, result.List())
+		}
+		if got := strings.Join(errs2strings(errs), "\n"); got != strings.Join(tc.errs, "\n") {
+			t.Errorf("%s: expected errors %q, got %q", tc.name, tc.errs, got)
+		}
+	}
+}
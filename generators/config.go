@@ -0,0 +1,327 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/gengo/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Known values for the precedence setting of the configuration file.
+const (
+	// PrecedenceConfig gives tags set in the configuration file precedence
+	// over in-source comment tags of the same name.  This is the default.
+	PrecedenceConfig = "config"
+	// PrecedenceSource gives in-source comment tags precedence over tags of
+	// the same name set in the configuration file.
+	PrecedenceSource = "source"
+)
+
+// Config holds generation options supplied through a configuration file
+// rather than through comment tags in the source.  This allows generation to
+// be requested for packages that cannot be edited, such as generated or
+// vendored code.  Each entry lists tags using the same syntax as the
+// equivalent comment tags, e.g. "+deepequal-gen=package" or
+// "+deepequal-gen:unordered-array=true"; the leading "+" is optional.
+type Config struct {
+	// Precedence decides which value is used when the same tag is set both
+	// in the source and in the configuration file.
+	Precedence string          `json:"precedence,omitempty"`
+	Packages   []PackageConfig `json:"packages,omitempty"`
+}
+
+// PackageConfig holds the options of a single package, identified by its
+// import path.
+type PackageConfig struct {
+	Path  string       `json:"path"`
+	Tags  []string     `json:"tags,omitempty"`
+	Types []TypeConfig `json:"types,omitempty"`
+}
+
+// TypeConfig holds the options of a single named type.
+type TypeConfig struct {
+	Name   string        `json:"name"`
+	Tags   []string      `json:"tags,omitempty"`
+	Fields []FieldConfig `json:"fields,omitempty"`
+}
+
+// FieldConfig holds the options of a single struct field.
+type FieldConfig struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// activeConfig is the configuration consulted by every tag lookup.  It is
+// installed by Packages and is nil when no configuration file was given.
+var activeConfig *Config
+
+// activeUniverse is the universe in which the package level tags of types are
+// looked up.  It is installed by Packages.
+var activeUniverse types.Universe
+
+// headerComments caches the comment lines preceding the package clause of the
+// doc.go file of each package, by import path.
+var headerComments = map[string][]string{}
+
+// installUniverse installs the universe in which the package level tags of
+// types are looked up.
+func installUniverse(universe types.Universe) {
+	activeUniverse = universe
+	headerComments = map[string][]string{}
+}
+
+// LoadConfig reads and validates the configuration file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	switch config.Precedence {
+	case "":
+		config.Precedence = PrecedenceConfig
+	case PrecedenceConfig, PrecedenceSource:
+	default:
+		return nil, fmt.Errorf("%s: unsupported precedence %q, expected %q or %q",
+			path, config.Precedence, PrecedenceConfig, PrecedenceSource)
+	}
+
+	for i := range config.Packages {
+		pkg := &config.Packages[i]
+		if pkg.Path == "" {
+			return nil, fmt.Errorf("%s: packages[%d]: missing path", path, i)
+		}
+		pkg.Tags = normalizeTags(pkg.Tags)
+		for j := range pkg.Types {
+			t := &pkg.Types[j]
+			if t.Name == "" {
+				return nil, fmt.Errorf("%s: package %s: types[%d]: missing name", path, pkg.Path, j)
+			}
+			t.Tags = normalizeTags(t.Tags)
+			for k := range t.Fields {
+				f := &t.Fields[k]
+				if f.Name == "" {
+					return nil, fmt.Errorf("%s: type %s.%s: fields[%d]: missing name", path, pkg.Path, t.Name, k)
+				}
+				f.Tags = normalizeTags(f.Tags)
+			}
+		}
+	}
+
+	return config, nil
+}
+
+// normalizeTags prefixes every tag with the "+" marker expected by the
+// comment tag parser.
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if !strings.HasPrefix(tag, "+") {
+			tag = "+" + tag
+		}
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// PackagePaths returns the import paths of all configured packages.
+func (c *Config) PackagePaths() []string {
+	if c == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(c.Packages))
+	for _, pkg := range c.Packages {
+		paths = append(paths, pkg.Path)
+	}
+	return paths
+}
+
+func (c *Config) lookupPackage(path string) *PackageConfig {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Packages {
+		if c.Packages[i].Path == path {
+			return &c.Packages[i]
+		}
+	}
+	return nil
+}
+
+func (c *Config) lookupType(name types.Name) *TypeConfig {
+	pkg := c.lookupPackage(name.Package)
+	if pkg == nil {
+		return nil
+	}
+	for i := range pkg.Types {
+		if pkg.Types[i].Name == name.Name {
+			return &pkg.Types[i]
+		}
+	}
+	return nil
+}
+
+func (c *Config) lookupField(name types.Name, member string) *FieldConfig {
+	t := c.lookupType(name)
+	if t == nil {
+		return nil
+	}
+	for i := range t.Fields {
+		if t.Fields[i].Name == member {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// merge combines in-source comment lines with the tags set in the
+// configuration file.  Whenever both set the same tag, only the values from
+// the side given precedence are kept.  Tags written with the "+k8s:" prefix
+// are read as their canonical form.
+func (c *Config) merge(source, configured []string) []string {
+	source, configured = canonicalTags(source), canonicalTags(configured)
+	if len(configured) == 0 {
+		return source
+	}
+
+	preferred, other := configured, source
+	if c.Precedence == PrecedenceSource {
+		preferred, other = source, configured
+	}
+
+	preferredTags := types.ExtractCommentTags("+", preferred)
+	merged := append([]string{}, preferred...)
+	for _, line := range other {
+		overridden := false
+		for name := range types.ExtractCommentTags("+", []string{line}) {
+			if _, found := preferredTags[name]; found {
+				overridden = true
+			}
+		}
+		if !overridden {
+			merged = append(merged, line)
+		}
+	}
+	return merged
+}
+
+// validate checks that every configured type and field exists in the
+// universe, so that typos in the configuration file do not go unnoticed.
+func (c *Config) validate(universe types.Universe) error {
+	if c == nil {
+		return nil
+	}
+	for _, pkgConfig := range c.Packages {
+		pkg := universe[pkgConfig.Path]
+		if pkg == nil {
+			return fmt.Errorf("configured package %q was not found", pkgConfig.Path)
+		}
+		for _, typeConfig := range pkgConfig.Types {
+			t := pkg.Types[typeConfig.Name]
+			if t == nil {
+				return fmt.Errorf("configured type %s.%s was not found", pkgConfig.Path, typeConfig.Name)
+			}
+			for _, fieldConfig := range typeConfig.Fields {
+				found := false
+				for _, m := range underlyingType(t).Members {
+					if m.Name == fieldConfig.Name {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return fmt.Errorf("configured field %s.%s.%s was not found", pkgConfig.Path, typeConfig.Name, fieldConfig.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// packageComments returns the comment lines carrying the tags of pkg.
+func packageComments(pkg *types.Package) []string {
+	var configured []string
+	if pkgConfig := activeConfig.lookupPackage(pkg.Path); pkgConfig != nil {
+		configured = pkgConfig.Tags
+	}
+	return activeConfig.merge(pkg.Comments, configured)
+}
+
+// typeComments returns the comment lines carrying the tags of type t.
+func typeComments(t *types.Type) []string {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	var configured []string
+	if typeConfig := activeConfig.lookupType(t.Name); typeConfig != nil {
+		configured = typeConfig.Tags
+	}
+	return activeConfig.merge(comments, configured)
+}
+
+// memberComments returns the comment lines carrying the tags of member m of
+// struct type t.
+func memberComments(t *types.Type, m *types.Member) []string {
+	var configured []string
+	if fieldConfig := activeConfig.lookupField(t.Name, m.Name); fieldConfig != nil {
+		configured = fieldConfig.Tags
+	}
+	return activeConfig.merge(m.CommentLines, configured)
+}
+
+// typePackageComments returns the comment lines carrying the package level
+// defaults of the tags of type t.  These are the tags of the package declaring
+// t, limited to the comments preceding the package clause of its doc.go file
+// so that the tags of types declared in doc.go are not mistaken for defaults.
+func typePackageComments(t *types.Type) []string {
+	pkg := activeUniverse[t.Name.Package]
+	if pkg == nil {
+		return nil
+	}
+	return packageHeaderComments(pkg)
+}
+
+// packageHeaderComments returns the comment lines written before the package
+// clause of doc.go in package pkg, merged with the tags configured for it.
+func packageHeaderComments(pkg *types.Package) []string {
+	comments, found := headerComments[pkg.Path]
+	if !found {
+		comments = parseHeaderComments(filepath.Join(pkg.SourcePath, "doc.go"))
+		headerComments[pkg.Path] = comments
+	}
+	var configured []string
+	if pkgConfig := activeConfig.lookupPackage(pkg.Path); pkgConfig != nil {
+		configured = pkgConfig.Tags
+	}
+	return activeConfig.merge(comments, configured)
+}
+
+// parseHeaderComments returns the comment lines preceding the package clause
+// of the Go file at path, or nil if there is no such file.
+func parseHeaderComments(path string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, group := range f.Comments {
+		if group.Pos() < f.Package {
+			lines = append(lines, strings.Split(strings.TrimSuffix(group.Text(), "\n"), "\n")...)
+		}
+	}
+	return lines
+}
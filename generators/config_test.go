@@ -0,0 +1,111 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_configMerge(t *testing.T) {
+	source := []string{
+		"Human comment",
+		"+deepequal-gen=true",
+		"+deepequal-gen:unordered-array=true",
+	}
+	configured := []string{
+		"+deepequal-gen:unordered-array=false",
+		"+deepequal-gen:ignore-nil-fields=true",
+	}
+
+	testCases := []struct {
+		precedence string
+		configured []string
+		expect     []string
+	}{
+		{
+			precedence: PrecedenceConfig,
+			configured: nil,
+			expect:     source,
+		},
+		{
+			precedence: PrecedenceConfig,
+			configured: configured,
+			expect: []string{
+				"+deepequal-gen:unordered-array=false",
+				"+deepequal-gen:ignore-nil-fields=true",
+				"Human comment",
+				"+deepequal-gen=true",
+			},
+		},
+		{
+			precedence: PrecedenceSource,
+			configured: configured,
+			expect: []string{
+				"Human comment",
+				"+deepequal-gen=true",
+				"+deepequal-gen:unordered-array=true",
+				"+deepequal-gen:ignore-nil-fields=true",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		c := &Config{Precedence: tc.precedence}
+		r := c.merge(source, tc.configured)
+		if !reflect.DeepEqual(r, tc.expect) {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_normalizeTags(t *testing.T) {
+	r := normalizeTags([]string{"deepequal-gen=package", " +deepequal-gen:unordered-array=true "})
+	expect := []string{"+deepequal-gen=package", "+deepequal-gen:unordered-array=true"}
+	if !reflect.DeepEqual(r, expect) {
+		t.Errorf("expected %q, got %q", expect, r)
+	}
+}
+
+func Test_parseHeaderComments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepequal-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "doc.go")
+	src := `/* License */
+
+// +deepequal-gen=package
+// +deepequal-gen:unordered-array=true
+
+// Package foo is a test package.
+package foo
+
+// +deepequal-gen:unordered-array=false
+type List []string
+`
+	if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []string{
+		" License",
+		"+deepequal-gen=package",
+		"+deepequal-gen:unordered-array=true",
+		"Package foo is a test package.",
+	}
+	if got := parseHeaderComments(path); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+	if got := parseHeaderComments(filepath.Join(dir, "missing.go")); got != nil {
+		t.Errorf("expected no comments for a missing file, got %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+func extractCycleSafeTypeTag(t *types.Type) *enabledTagValue {
+	return extractTypeOrPackageTag(t, extractCycleSafeTag)
+}
+
+func extractCycleSafeTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagCycleSafeTagName, comments)
+}
+
+// visitedMethodName returns the name of the variant of the DeepEqual method
+// of type t which carries the set of visited pointer pairs.
+func visitedMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepEqualVisited"
+	}
+	return "DeepEqualVisited"
+}
+
+// cycleSafeTypes returns the types whose comparison detects cycles: the
+// generated types which opted in with the cycle-safe tag, and the generated
+// types they delegate to, so that a cycle through any of them is detected.
+func cycleSafeTypes(generated []*types.Type, policy *comparisonPolicy) sets.String {
+	result := sets.NewString()
+
+	var walk func(t *types.Type)
+	walk = func(t *types.Type) {
+		nested := []*types.Type{}
+		for _, d := range delegates(t) {
+			nested = append(nested, d.t)
+		}
+		for _, nt := range nestedValueTypes(t) {
+			// Named slices and maps generated by this run are compared inline
+			// or by calling their method depending on the generation order.
+			if k := underlyingType(nt).Kind; k == types.Slice || k == types.Map {
+				nested = append(nested, nt)
+			}
+		}
+		for _, dt := range nested {
+			if result.Has(dt.Name.String()) || !policy.generating.Has(dt.Name.String()) || deepEqualMethodOrDie(dt) != nil {
+				continue
+			}
+			result.Insert(dt.Name.String())
+			walk(dt)
+		}
+	}
+
+	for _, t := range generated {
+		tag := extractCycleSafeTypeTag(t)
+		if tag == nil || tag.value != "true" || deepEqualMethodOrDie(t) != nil || result.Has(t.Name.String()) {
+			continue
+		}
+		result.Insert(t.Name.String())
+		walk(t)
+	}
+	return result
+}
+
+// visitedMethod returns the name of the method comparing nested values of
+// type t while carrying the visited set, or an empty string if the type has
+// none and is compared with its DeepEqual method.
+func (g *genDeepEqual) visitedMethod(t *types.Type) string {
+	name := visitedMethodName(t)
+	if g.policy.cycleSafe.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	if g.policy.hasGeneratedMethod(t, name) {
+		return name
+	}
+	return ""
+}
+
+// doVisited generates the DeepEqual method of type t, and the variant of it
+// carrying the visited set.  The DeepEqual method of a type which opted in
+// with the cycle-safe tag starts a comparison with an empty visited set, while
+// the types it delegates to keep their plain DeepEqual method.
+func (g *genDeepEqual) doVisited(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["method"] = deepEqualMethodName(t)
+	args["visited"] = visitedMethodName(t)
+
+	sw.Do("// $.method$ is an autogenerated deepequal function, deeply comparing the\n", args)
+	sw.Do("// receiver with other. in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.method$(other *$.type|raw$) bool {\n", args)
+	if tag := extractCycleSafeTypeTag(t); tag != nil && tag.value == "true" {
+		sw.Do("return in.$.visited$(other, map[interface{}]bool{})\n", args)
+	} else {
+		g.generateFor(t, sw)
+		sw.Do("\nreturn true\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+
+	klog.V(5).Infof("Generating %s function for type %v", args["visited"], t)
+	sw.Do("// $.visited$ is an autogenerated deepequal function, comparing the\n", args)
+	sw.Do("// receiver with other like $.method$.  visited records the pairs of values\n", args)
+	sw.Do("// already being compared, which are considered equal when they are reached\n", nil)
+	sw.Do("// again through a cycle.  Pairs found to differ are removed, so that they\n", nil)
+	sw.Do("// are not considered equal when an unordered slice compares them again.\n", nil)
+	sw.Do("// in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.visited$(other *$.type|raw$, visited map[interface{}]bool) (equal bool) {\n", args)
+	sw.Do("key := [2]*$.type|raw${in, other}\n", args)
+	sw.Do("if visited[key] {\n", nil)
+	sw.Do("return true\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("visited[key] = true\n", nil)
+	sw.Do("defer func() {\n", nil)
+	sw.Do("if !equal {\n", nil)
+	sw.Do("delete(visited, key)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}()\n\n", nil)
+	g.visited = true
+	g.generateFor(t, sw)
+	g.visited = false
+	sw.Do("\nreturn true\n", nil)
+	sw.Do("}\n\n", nil)
+}
@@ -0,0 +1,55 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_cycleSafeTypes(t *testing.T) {
+	node := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Node"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:cycle-safe=true"},
+	}
+	edge := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Edge"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "To", Type: &types.Type{Kind: types.Pointer, Elem: node}},
+		},
+	}
+	list := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "List"},
+		Kind: types.Struct,
+	}
+	list.Members = []types.Member{
+		{Name: "Next", Type: &types.Type{Kind: types.Pointer, Elem: list}},
+	}
+	group := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Group"},
+		Kind: types.Slice,
+		Elem: &types.Type{Kind: types.Pointer, Elem: node},
+	}
+	node.Members = []types.Member{
+		{Name: "Parent", Type: &types.Type{Kind: types.Pointer, Elem: node}},
+		{Name: "Edges", Type: &types.Type{Kind: types.Slice, Elem: edge}},
+		{Name: "Peers", Type: group},
+	}
+
+	policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+	for _, t := range []*types.Type{node, edge, group, list} {
+		policy.generating.Insert(t.Name.String())
+	}
+	cycleSafe := cycleSafeTypes([]*types.Type{edge, group, list, node}, policy)
+	if want := []string{"example.com/api.Edge", "example.com/api.Group", "example.com/api.Node"}; strings.Join(cycleSafe.List(), ",") != strings.Join(want, ",") {
+		t.Errorf("expected cycle-safe types %v, got %v", want, cycleSafe.List())
+	}
+}
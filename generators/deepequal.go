@@ -9,7 +9,9 @@ package generators
 import (
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"k8s.io/gengo/args"
@@ -26,6 +28,12 @@ import (
 type CustomArgs struct {
 	BoundingDirs   []string // Only deal with types rooted under these dirs.
 	GenPackagePath string   // Overwritten package path to be generated
+	ConfigFile     string   // Configuration file supplementing comment tags
+	Config         *Config  // Configuration loaded from ConfigFile
+	InputCacheFile string   // File recording the input hash of each package
+	Force          bool     // Regenerate packages even if their inputs did not change
+	Transitive     bool     // Also generate for all in-bounds types reachable from generated types
+	Fallback       string   // Comparison of out-of-bounds types which are not comparable with ==
 }
 
 // This is the comment tag that carries parameters for deep-copy generation.
@@ -33,6 +41,19 @@ const (
 	tagEnabledName            = "deepequal-gen"
 	tagIgnoreNilFieldsTagName = tagEnabledName + ":ignore-nil-fields"
 	tagUnorderedArraysTagName = tagEnabledName + ":unordered-array"
+	tagProfileTagName         = tagEnabledName + ":profile"
+	tagIgnoreUnexportedName   = tagEnabledName + ":ignore-unexported-fields"
+	tagLockTagName            = tagEnabledName + ":lock"
+	tagMatchesTagName         = tagEnabledName + ":matches"
+	tagCycleSafeTagName       = tagEnabledName + ":cycle-safe"
+	tagCompareTagName         = tagEnabledName + ":compare"
+	tagNormalizeTagName       = tagEnabledName + ":normalize"
+	tagFieldMaskTagName       = tagEnabledName + ":field-mask"
+	tagChangedFieldsTagName   = tagEnabledName + ":changed-fields"
+	tagMergePatchTagName      = tagEnabledName + ":merge-patch"
+	tagSemanticsTagName       = tagEnabledName + ":semantics"
+	tagNilEqualsEmptyTagName  = tagEnabledName + ":nil-equals-empty"
+	tagIsZeroTagName          = tagEnabledName + ":is-zero"
 )
 
 // Known values for the comment tag.
@@ -45,8 +66,7 @@ type enabledTagValue struct {
 }
 
 func extractEnabledTypeTag(t *types.Type) *enabledTagValue {
-	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
-	return extractEnabledTag(comments)
+	return extractEnabledTag(typeComments(t))
 }
 
 func extractEnabledTag(comments []string) *enabledTagValue {
@@ -90,64 +110,324 @@ func extractEnabledTag(comments []string) *enabledTagValue {
 	return tag
 }
 
-func extractUnorderedArrayTypeTag(t *types.Type) *enabledTagValue {
-	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
-	return extractUnorderedArrayTag(comments)
-}
-
-func extractUnorderedArrayTag(comments []string) *enabledTagValue {
-	tagVals := types.ExtractCommentTags("+", comments)[tagUnorderedArraysTagName]
+// extractSingleValueTag returns the value of tag name in comments, or nil if
+// the comments do not set it.  The tag takes a single value.
+func extractSingleValueTag(name string, comments []string) *enabledTagValue {
+	tagVals := types.ExtractCommentTags("+", comments)[name]
 	if tagVals == nil {
 		// No match for the tag.
 		return nil
 	}
 	// If there are multiple values, abort.
 	if len(tagVals) > 1 {
-		klog.Fatalf("Found %d %s tags: %q", len(tagVals), tagUnorderedArraysTagName, tagVals)
+		klog.Fatalf("Found %d %s tags: %q", len(tagVals), name, tagVals)
 	}
 
-	// If we got here we are returning something.
-	tag := &enabledTagValue{}
-
 	// Get the tag value.
 	parts := strings.Split(tagVals[0], ",")
 	if len(parts) > 1 {
-		klog.Fatalf("Found %d %s tag values: %q", len(tagVals[0]), tagUnorderedArraysTagName, tagVals)
+		klog.Fatalf("Found %d %s tag values: %q", len(parts), name, tagVals)
 	}
 
-	tag.value = parts[0]
+	return &enabledTagValue{value: parts[0]}
+}
 
-	return tag
+// extractTypeOrPackageTag returns the tag of type t extracted by extract, or
+// the default set for every type by the package declaring t if the type does
+// not set it.
+func extractTypeOrPackageTag(t *types.Type, extract func(comments []string) *enabledTagValue) *enabledTagValue {
+	if tag := extract(typeComments(t)); tag != nil {
+		return tag
+	}
+	return extract(typePackageComments(t))
+}
+
+func extractUnorderedArrayTypeTag(t *types.Type) *enabledTagValue {
+	return extractTypeOrPackageTag(t, extractUnorderedArrayTag)
+}
+
+func extractUnorderedArrayMemberTag(t *types.Type, m *types.Member) *enabledTagValue {
+	return extractUnorderedArrayTag(memberComments(t, m))
+}
+
+func extractUnorderedArrayTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagUnorderedArraysTagName, comments)
 }
 
 func extractIgnoreNilFieldsTypeTag(t *types.Type) *enabledTagValue {
-	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
-	return extractIgnoreNilFieldsTag(comments)
+	return extractTypeOrPackageTag(t, extractIgnoreNilFieldsTag)
+}
+
+func extractIgnoreNilFieldsMemberTag(t *types.Type, m *types.Member) *enabledTagValue {
+	return extractIgnoreNilFieldsTag(memberComments(t, m))
+}
+
+// Known values of the ignore-nil-fields mode of a field.
+const (
+	// ignoreNilLeft skips a pointer field when it is nil in the receiver.
+	ignoreNilLeft = "true"
+	// ignoreNilEither skips a pointer, slice, map or interface field when it
+	// is nil in either value, so that the comparison is symmetric.
+	ignoreNilEither = "either"
+)
+
+// ignoreNilMode returns the ignore-nil-fields mode of member m of struct t,
+// given the tag of the struct, or an empty string if nil values of the member
+// are compared.  A field level tag overrides the type level tag.
+func ignoreNilMode(typeTag *enabledTagValue, t *types.Type, m *types.Member) string {
+	tag := typeTag
+	if memberTag := extractIgnoreNilFieldsMemberTag(t, m); memberTag != nil {
+		tag = memberTag
+	}
+	if tag == nil {
+		return ""
+	}
+	switch tag.value {
+	case ignoreNilEither:
+		return ignoreNilEither
+	case ignoreNilLeft:
+		if underlyingType(m.Type).Kind == types.Pointer {
+			return ignoreNilLeft
+		}
+	}
+	return ""
 }
 
 func extractIgnoreNilFieldsTag(comments []string) *enabledTagValue {
-	tagVals := types.ExtractCommentTags("+", comments)[tagIgnoreNilFieldsTagName]
+	return extractSingleValueTag(tagIgnoreNilFieldsTagName, comments)
+}
+
+func extractIgnoreUnexportedFieldsTypeTag(t *types.Type) *enabledTagValue {
+	return extractTypeOrPackageTag(t, extractIgnoreUnexportedFieldsTag)
+}
+
+func extractIgnoreUnexportedFieldsTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagIgnoreUnexportedName, comments)
+}
+
+func extractLockTypeTag(t *types.Type) *enabledTagValue {
+	return extractLockTag(typeComments(t))
+}
+
+func extractLockTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagLockTagName, comments)
+}
+
+// ignoresMember returns whether member m of struct type t is left out of the
+// comparison because it is unexported and t ignores unexported fields, or
+// because t is compared like its JSON encoding which leaves m out.  Embedded
+// fields are named after their type, so embedding an unexported type declares
+// an unexported field.
+func ignoresMember(t *types.Type, m *types.Member) bool {
+	if jsonSemantics(t) && jsonOmitsMember(m) {
+		return true
+	}
+	if !namer.IsPrivateGoName(m.Name) {
+		return false
+	}
+	tag := extractIgnoreUnexportedFieldsTypeTag(t)
+	return tag != nil && tag.value == "true"
+}
+
+// profileTagValue holds the parameters of a single tagProfileTagName tag.
+type profileTagValue struct {
+	name   string
+	ignore []string
+}
+
+// ignores returns whether the named struct member is excluded from the
+// comparison performed by this profile.
+func (p *profileTagValue) ignores(member string) bool {
+	for _, name := range p.ignore {
+		if name == member {
+			return true
+		}
+	}
+	return false
+}
+
+// nested returns the profile comparing struct member member in place, which
+// ignores the fields of the member named by dotted paths, or nil if this
+// profile ignores none of them.
+func (p *profileTagValue) nested(member string) *profileTagValue {
+	var ignore []string
+	for _, name := range p.ignore {
+		if strings.HasPrefix(name, member+".") {
+			ignore = append(ignore, strings.TrimPrefix(name, member+"."))
+		}
+	}
+	if ignore == nil {
+		return nil
+	}
+	return &profileTagValue{name: p.name, ignore: ignore}
+}
+
+// resolveProfileIgnore returns the dotted path of the field of struct type t
+// named by name in the ignore parameter of profile: a member of t, a field
+// promoted from an embedded struct, such as ResourceVersion, or a path through
+// struct fields, such as ObjectMeta.ResourceVersion.  The structs holding an
+// ignored field are compared in place, so they must be struct values whose
+// comparison is generated.
+func resolveProfileIgnore(t *types.Type, profile, name string, policy *comparisonPolicy) (string, error) {
+	pkg := t.Name.Package
+	var path []string
+	for i, part := range strings.Split(name, ".") {
+		ut := underlyingType(t)
+		if i > 0 {
+			// Every field of the path before the last one is inlined.
+			if err := inlinableProfileField(t, pkg, profile, name, policy); err != nil {
+				return "", err
+			}
+		}
+		members, err := findProfileMember(ut, part)
+		if err != nil {
+			return "", fmt.Errorf("ignores %s", err)
+		}
+		if members == nil {
+			return "", fmt.Errorf("ignores unknown field %q, name fields of nested structs by their path, such as ObjectMeta.ResourceVersion", name)
+		}
+		for _, m := range members[:len(members)-1] {
+			if err := inlinableProfileField(m.Type, pkg, profile, name, policy); err != nil {
+				return "", err
+			}
+			path = append(path, m.Name)
+		}
+		m := members[len(members)-1]
+		path = append(path, m.Name)
+		t = m.Type
+	}
+	return strings.Join(path, "."), nil
+}
+
+// findProfileMember returns the members leading from struct type t to its
+// field named name: the member itself, or the embedded structs promoting it
+// followed by the field.  It returns nil if t has no such field.
+func findProfileMember(t *types.Type, name string) ([]*types.Member, error) {
+	level := [][]*types.Member{nil}
+	for len(level) > 0 {
+		var found, next [][]*types.Member
+		for _, members := range level {
+			st := t
+			if len(members) > 0 {
+				st = underlyingType(members[len(members)-1].Type)
+			}
+			for i := range st.Members {
+				m := &st.Members[i]
+				path := append(append([]*types.Member{}, members...), m)
+				if m.Name == name {
+					found = append(found, path)
+				} else if m.Embedded && underlyingType(m.Type).Kind == types.Struct {
+					next = append(next, path)
+				}
+			}
+		}
+		switch len(found) {
+		case 0:
+			level = next
+		case 1:
+			return found[0], nil
+		default:
+			return nil, fmt.Errorf("ambiguous field %q, name it by its path", name)
+		}
+	}
+	return nil, nil
+}
+
+// inlinableProfileField returns an error unless a field of type t holding a
+// field ignored by profile can be compared in place by the code generated in
+// package pkg.
+func inlinableProfileField(t *types.Type, pkg, profile, name string, policy *comparisonPolicy) error {
+	ut := underlyingType(t)
+	if ut.Kind != types.Struct {
+		return fmt.Errorf("cannot ignore %q: %v is not a struct", name, t)
+	}
+	if !policy.generating.Has(t.Name.String()) && deepEqualMethodOrDie(t) != nil {
+		return fmt.Errorf("cannot ignore %q: %v has its own %s method", name, t, deepEqualMethodName(t))
+	}
+	if _, found := t.Methods[profile]; found && !hasProfileTag(t, profile) {
+		return fmt.Errorf("cannot ignore %q: %v has its own %s method", name, t, profile)
+	}
+	if t.Name.Package != pkg {
+		for i := range ut.Members {
+			if m := &ut.Members[i]; namer.IsPrivateGoName(m.Name) && !ignoresMember(ut, m) {
+				return fmt.Errorf("cannot ignore %q: %v has unexported fields", name, t)
+			}
+		}
+	}
+	return nil
+}
+
+func extractProfileTypeTags(t *types.Type) []*profileTagValue {
+	return extractProfileTags(typeComments(t))
+}
+
+func extractProfileTags(comments []string) []*profileTagValue {
+	tagVals := types.ExtractCommentTags("+", comments)[tagProfileTagName]
 	if tagVals == nil {
 		// No match for the tag.
 		return nil
 	}
-	// If there are multiple values, abort.
-	if len(tagVals) > 1 {
-		klog.Fatalf("Found %d %s tags: %q", len(tagVals), tagIgnoreNilFieldsTagName, tagVals)
-	}
 
-	// If we got here we are returning something.
-	tag := &enabledTagValue{}
+	profiles := make([]*profileTagValue, 0, len(tagVals))
+	for _, tagVal := range tagVals {
+		tag := &profileTagValue{}
 
-	// Get the tag value.
-	parts := strings.Split(tagVals[0], ",")
-	if len(parts) > 1 {
-		klog.Fatalf("Found %d %s tag values: %q", len(tagVals[0]), tagIgnoreNilFieldsTagName, tagVals)
+		// Get the profile name which doubles as the generated method name.
+		parts := strings.Split(tagVal, ",")
+		tag.name = parts[0]
+		if tag.name == "" || tag.name == "DeepEqual" || namer.IsPrivateGoName(tag.name) {
+			klog.Fatalf("Unsupported %s name: %q", tagProfileTagName, tag.name)
+		}
+		for _, profile := range profiles {
+			if profile.name == tag.name {
+				klog.Fatalf("Found duplicate %s tags: %q", tagProfileTagName, tag.name)
+			}
+		}
+
+		// Parse extra arguments.
+		parts = parts[1:]
+		for i := range parts {
+			kv := strings.SplitN(parts[i], "=", 2)
+			k := kv[0]
+			v := ""
+			if len(kv) == 2 {
+				v = kv[1]
+			}
+			switch k {
+			case "ignore":
+				for _, name := range strings.Split(v, ";") {
+					if name = strings.TrimSpace(name); name != "" {
+						tag.ignore = append(tag.ignore, name)
+					}
+				}
+			default:
+				klog.Fatalf("Unsupported %s param: %q", tagProfileTagName, parts[i])
+			}
+		}
+		profiles = append(profiles, tag)
 	}
+	return profiles
+}
 
-	tag.value = parts[0]
+// hasProfileTag returns whether type t is tagged to have a comparison method
+// generated for the named profile.
+func hasProfileTag(t *types.Type, name string) bool {
+	for _, profile := range extractProfileTypeTags(t) {
+		if profile.name == name {
+			return true
+		}
+	}
+	return false
+}
 
-	return tag
+// hasProfile returns whether a comparison method for the named profile is
+// available on type t, either because it is tagged to have one generated or
+// because it already exists.
+func hasProfile(t *types.Type, name string) bool {
+	if _, found := t.Methods[name]; found {
+		return true
+	}
+	return hasProfileTag(t, name)
 }
 
 // NameSystems returns the name system used by the generators in this package.
@@ -186,6 +466,16 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 		}
 	}
 
+	// Decide how nested values of types outside the bounding dirs are compared.
+	fallback := FallbackReflect
+	if customArgs, ok := arguments.CustomArgs.(*CustomArgs); ok && len(customArgs.Fallback) > 0 {
+		fallback = customArgs.Fallback
+	}
+	if fallback != FallbackReflect && fallback != FallbackError {
+		klog.Fatalf("Unsupported fallback %q, expected %q or %q", fallback, FallbackReflect, FallbackError)
+	}
+	policy := newComparisonPolicy(boundingDirs, fallback, inputs, arguments.GeneratedBuildTag)
+
 	// Obtain override package path value
 	genPackagePath := ""
 	if customArgs, ok := arguments.CustomArgs.(*CustomArgs); ok {
@@ -194,6 +484,104 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 		}
 	}
 
+	// Report out-of-date files as unified diffs rather than only the first
+	// difference.
+	if arguments.VerifyOnly {
+		context.FileTypes[generator.GolangFileType] = newVerifyFileType(os.Stdout)
+	}
+
+	// Install the configuration file, if any, so that it is consulted along
+	// with the comment tags.
+	installUniverse(context.Universe)
+	if customArgs, ok := arguments.CustomArgs.(*CustomArgs); ok {
+		activeConfig = customArgs.Config
+		if err := activeConfig.validate(context.Universe); err != nil {
+			klog.Fatalf("Invalid configuration file %s: %v", customArgs.ConfigFile, err)
+		}
+	}
+
+	// Report every misspelled or misplaced tag at once, as they would
+	// otherwise be silently ignored.
+	if errs := lintTags(context.Universe, inputs.List()); len(errs) > 0 {
+		klog.Fatalf("Found %d invalid %s tags:\n%s", len(errs), tagEnabledName, strings.Join(errs2strings(errs), "\n"))
+	}
+
+	// Find the types generated by this run, so that the comparisons delegated
+	// to them can be checked before anything is written.
+	roots := []*types.Type{}
+	for _, i := range inputs.List() {
+		pkg := context.Universe[i]
+		if pkg == nil {
+			continue
+		}
+		ptag := extractEnabledTag(packageComments(pkg))
+		for _, t := range pkg.Types {
+			ttag := extractEnabledTypeTag(t)
+			enabled := (ptag != nil && ptag.value == tagValuePackage) || (ttag != nil && ttag.value == "true")
+			if enabled && comparableType(t) {
+				roots = append(roots, t)
+				policy.generating.Insert(t.Name.String())
+			}
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Name.String() < roots[j].Name.String() })
+
+	// In transitive mode, also generate for every in-bounds type which the
+	// generated code depends on.
+	transitiveTypes := sets.NewString()
+	var errs []error
+	if customArgs, ok := arguments.CustomArgs.(*CustomArgs); ok && customArgs.Transitive {
+		policy.transitive = true
+		transitiveTypes, errs = reachableTypes(roots, policy)
+		for _, name := range transitiveTypes.List() {
+			klog.V(3).Infof("Type %s is reachable from a generated type", name)
+		}
+		policy.generating.Insert(transitiveTypes.UnsortedList()...)
+	} else {
+		errs = checkDelegates(roots, policy)
+	}
+	if len(errs) > 0 {
+		klog.Fatalf("The generated code cannot compare %d nested values:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+
+	// Find the types which get a DeepMatches method, including the generated
+	// types nested in the types which opted in.
+	generated := []*types.Type{}
+	for _, pkg := range context.Universe {
+		for _, t := range pkg.Types {
+			if policy.generating.Has(t.Name.String()) {
+				generated = append(generated, t)
+			}
+		}
+	}
+	sort.Slice(generated, func(i, j int) bool { return generated[i].Name.String() < generated[j].Name.String() })
+	policy.matching = matchingTypes(generated, policy)
+	policy.cycleSafe = cycleSafeTypes(generated, policy)
+	policy.ordering, errs = orderingTypes(generated, policy)
+	if len(errs) > 0 {
+		klog.Fatalf("Found %d types which cannot be ordered:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+	policy.normalizing, errs = normalizingTypes(generated, policy)
+	if len(errs) > 0 {
+		klog.Fatalf("Found %d types which cannot be normalized:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+	policy.masking, errs = maskingTypes(generated, policy)
+	if len(errs) > 0 {
+		klog.Fatalf("Found %d types which cannot be masked:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+	policy.changedFields, errs = changedFieldsTypes(generated, policy)
+	if len(errs) > 0 {
+		klog.Fatalf("Found %d types whose changed fields cannot be reported:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+	policy.mergePatching, errs = mergePatchTypes(generated, policy)
+	if len(errs) > 0 {
+		klog.Fatalf("Found %d types which cannot be merge patched:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+	policy.zeroTesting, errs = zeroTestingTypes(generated, policy)
+	if len(errs) > 0 {
+		klog.Fatalf("Found %d types which cannot be tested for zero values:\n%s", len(errs), strings.Join(errs2strings(errs), "\n"))
+	}
+
 	for i := range inputs {
 		klog.V(5).Infof("Considering pkg %q", i)
 		pkg := context.Universe[i]
@@ -202,7 +590,7 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 			continue
 		}
 
-		ptag := extractEnabledTag(pkg.Comments)
+		ptag := extractEnabledTag(packageComments(pkg))
 		ptagValue := ""
 		ptagRegister := false
 		if ptag != nil {
@@ -232,6 +620,11 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 					pkgNeedsGeneration = true
 					break
 				}
+				if transitiveTypes.Has(t.Name.String()) {
+					klog.V(5).Infof("    reachable")
+					pkgNeedsGeneration = true
+					break
+				}
 			}
 		}
 
@@ -262,7 +655,7 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 					HeaderText:  header,
 					GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
 						return []generator.Generator{
-							NewGenDeepEqual(arguments.OutputFileBaseName, pkg.Path, boundingDirs, ptagValue == tagValuePackage, ptagRegister),
+							NewGenDeepEqual(arguments.OutputFileBaseName, pkg.Path, policy, ptagValue == tagValuePackage, ptagRegister, transitiveTypes),
 						}
 					},
 					FilterFunc: func(c *generator.Context, t *types.Type) bool {
@@ -278,21 +671,30 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 type genDeepEqual struct {
 	generator.DefaultGen
 	targetPackage string
-	boundingDirs  []string
+	policy        *comparisonPolicy
 	allTypes      bool
 	registerTypes bool
+	reachable     sets.String // Types generated because generated code depends on them.
 	imports       namer.ImportTracker
+	profile       *profileTagValue // The profile currently being generated, if any.
+	unordered     *enabledTagValue // Field level unordered-array tag of the slice being compared inline, if any.
+	path          string           // Path of the value being compared, for diagnostics.
+	visited       bool             // Whether the variant carrying the visited set is being generated.
+	ordered       bool             // Whether the type being compared also has a DeepCompare method.
+	masked        bool             // Whether the variant comparing the fields selected by a mask is being generated.
+	nils          *enabledTagValue // nil-equals-empty tag applying to the unstructured values being compared, if any.
 }
 
-func NewGenDeepEqual(sanitizedName, targetPackage string, boundingDirs []string, allTypes, registerTypes bool) generator.Generator {
+func NewGenDeepEqual(sanitizedName, targetPackage string, policy *comparisonPolicy, allTypes, registerTypes bool, reachable sets.String) generator.Generator {
 	return &genDeepEqual{
 		DefaultGen: generator.DefaultGen{
 			OptionalName: sanitizedName,
 		},
 		targetPackage: targetPackage,
-		boundingDirs:  boundingDirs,
+		policy:        policy,
 		allTypes:      allTypes,
 		registerTypes: registerTypes,
+		reachable:     reachable,
 		imports:       generator.NewImportTracker(),
 	}
 }
@@ -306,7 +708,7 @@ func (g *genDeepEqual) Namers(c *generator.Context) namer.NameSystems {
 
 func (g *genDeepEqual) Filter(c *generator.Context, t *types.Type) bool {
 	// Filter other types not being processed or not copyable within the package.
-	enabled := g.allTypes
+	enabled := g.allTypes || g.reachable.Has(t.Name.String())
 	if !enabled {
 		ttag := extractEnabledTypeTag(t)
 		if ttag != nil && ttag.value == "true" {
@@ -324,15 +726,14 @@ func (g *genDeepEqual) Filter(c *generator.Context, t *types.Type) bool {
 	return true
 }
 
-func (g *genDeepEqual) copyableAndInBounds(t *types.Type) bool {
-	if !comparableType(t) {
-		return false
-	}
-	// Only packages within the restricted range can be processed.
-	if !isRootedUnder(t.Name.Package, g.boundingDirs) {
-		return false
+// deepEqualMethodName returns the name of the DeepEqual method of type t.
+// Private types have an unexported deepEqual method instead, so that helper
+// types do not need to be exported to be compared.
+func deepEqualMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepEqual"
 	}
-	return true
+	return "DeepEqual"
 }
 
 // deepEqualMethod returns the signature of a DeepEqual() method, nil or an error
@@ -342,22 +743,24 @@ func (g *genDeepEqual) copyableAndInBounds(t *types.Type) bool {
 //    func (t T) DeepEqual(t *T)
 // or:
 //    func (t *T) DeepEqual(t *T)
+// For a private type the method is named deepEqual.
 func deepEqualMethod(t *types.Type) (*types.Signature, error) {
-	f, found := t.Methods["DeepEqual"]
+	name := deepEqualMethodName(t)
+	f, found := t.Methods[name]
 	if !found {
 		return nil, nil
 	}
 	if len(f.Signature.Parameters) != 1 {
-		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected exactly one parameter", t)
+		return nil, fmt.Errorf("type %v: invalid %s signature, expected exactly one parameter", t, name)
 	}
 	if len(f.Signature.Results) != 1 {
-		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected bool result type", t)
+		return nil, fmt.Errorf("type %v: invalid %s signature, expected bool result type", t, name)
 	}
 
 	ptrParam := f.Signature.Parameters[0].Kind == types.Pointer && f.Signature.Parameters[0].Elem.Name == t.Name
 
 	if !ptrParam {
-		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected parameter of type *%s", t, t.Name.Name)
+		return nil, fmt.Errorf("type %v: invalid %s signature, expected parameter of type *%s", t, name, t.Name.Name)
 	}
 
 	ptrRcvr := f.Signature.Receiver != nil && f.Signature.Receiver.Kind == types.Pointer && f.Signature.Receiver.Elem.Name == t.Name
@@ -365,7 +768,7 @@ func deepEqualMethod(t *types.Type) (*types.Signature, error) {
 
 	if !ptrRcvr && !nonPtrRcvr {
 		// this should never happen
-		return nil, fmt.Errorf("type %v: invalid DeepEqual signature, expected a receiver of type %s or *%s", t, t.Name.Name, t.Name.Name)
+		return nil, fmt.Errorf("type %v: invalid %s signature, expected a receiver of type %s or *%s", t, name, t.Name.Name, t.Name.Name)
 	}
 
 	return f.Signature, nil
@@ -400,8 +803,8 @@ func comparableType(t *types.Type) bool {
 		return false
 	}
 
-	// Filter other private types.
-	if namer.IsPrivateGoName(t.Name.Name) {
+	// Locks are never compared.
+	if isLockType(t) {
 		return false
 	}
 
@@ -453,6 +856,14 @@ func (g *genDeepEqual) Imports(c *generator.Context) (imports []string) {
 	return importLines
 }
 
+func errs2strings(errs []error) []string {
+	strs := make([]string, len(errs))
+	for i := range errs {
+		strs[i] = errs[i].Error()
+	}
+	return strs
+}
+
 func argsFromType(ts ...*types.Type) generator.Args {
 	a := generator.Args{
 		"type": ts[0],
@@ -476,6 +887,11 @@ func (g *genDeepEqual) needsGeneration(t *types.Type) bool {
 			klog.Fatalf("Type %v: unsupported %s value: %q", t, tagEnabledName, tag.value)
 		}
 	}
+	if g.reachable.Has(t.Name.String()) {
+		// The type was not selected, but generated code depends on it.
+		klog.V(5).Infof("Generating for type %v because it is reachable", t)
+		return true
+	}
 	if g.allTypes && tv == "false" {
 		// The whole package is being generated, but this type has opted other.
 		klog.V(5).Infof("Not generating for type %v because type opted other", t)
@@ -497,14 +913,100 @@ func (g *genDeepEqual) GenerateType(c *generator.Context, t *types.Type, w io.Wr
 
 	sw := generator.NewSnippetWriter(w, c, "$", "$")
 	typeArgs := argsFromType(t)
-
-	if deepEqualMethodOrDie(t) == nil {
-		sw.Do("// DeepEqual is an autogenerated deepequal function, deeply comparing the \n", nil)
+	typeArgs["method"] = deepEqualMethodName(t)
+	g.path = t.Name.String()
+	g.nils = extractNilEqualsEmptyTypeTag(t)
+
+	g.ordered = g.policy.ordering.Has(t.Name.String())
+	if deepEqualMethodOrDie(t) == nil && g.policy.cycleSafe.Has(t.Name.String()) {
+		g.doVisited(t, sw)
+	} else if deepEqualMethodOrDie(t) == nil {
+		sw.Do("// $.method$ is an autogenerated deepequal function, deeply comparing the \n", typeArgs)
 		sw.Do("// receiver with other. in must be non-nil.\n", nil)
-		sw.Do("func (in *$.type|raw$) DeepEqual(other *$.type|raw$) bool {\n", typeArgs)
+		sw.Do("func (in *$.type|raw$) $.method$(other *$.type|raw$) bool {\n", typeArgs)
+		g.generateFor(t, sw)
+		sw.Do("\nreturn true\n", nil)
+		sw.Do("}\n\n", nil)
+	}
+
+	g.ordered = false
+
+	if lock := extractLockTypeTag(t); lock != nil {
+		g.doLocked(t, lock.value, sw)
+	}
+
+	profiles := extractProfileTypeTags(t)
+	for _, profile := range profiles {
+		if _, found := t.Methods[profile.name]; found {
+			// The author has provided their own implementation.
+			continue
+		}
+		for i, name := range profile.ignore {
+			path, err := resolveProfileIgnore(t, profile.name, name, g.policy)
+			if err != nil {
+				klog.Fatalf("Type %v: %s %q %v", t, tagProfileTagName, profile.name, err)
+			}
+			profile.ignore[i] = path
+		}
+
+		klog.V(5).Infof("Generating %s profile function for type %v", profile.name, t)
+		profileArgs := argsFromType(t)
+		profileArgs["name"] = profile.name
+		profileArgs["ignore"] = strings.Join(profile.ignore, ", ")
+
+		g.profile = profile
+		sw.Do("// $.name$ is an autogenerated deepequal function for the $.name$ profile,\n", profileArgs)
+		sw.Do("// deeply comparing the receiver with other. in must be non-nil.\n", nil)
+		if len(profile.ignore) > 0 {
+			sw.Do("// The following fields are not compared: $.ignore$.\n", profileArgs)
+		}
+		sw.Do("func (in *$.type|raw$) $.name$(other *$.type|raw$) bool {\n", profileArgs)
 		g.generateFor(t, sw)
 		sw.Do("\nreturn true\n", nil)
 		sw.Do("}\n\n", nil)
+		g.profile = nil
+	}
+
+	if g.policy.matching.Has(t.Name.String()) {
+		if _, found := t.Methods[matchesMethodName(t)]; !found {
+			g.doMatches(t, sw)
+		}
+	}
+
+	if g.policy.ordering.Has(t.Name.String()) {
+		if _, found := t.Methods[compareMethodName(t)]; !found {
+			g.doOrdering(t, sw)
+		}
+	}
+
+	if g.policy.normalizing.Has(t.Name.String()) {
+		if _, found := t.Methods[normalizeMethodName(t)]; !found {
+			g.doNormalize(t, sw)
+		}
+	}
+
+	if g.policy.masking.Has(t.Name.String()) {
+		if _, found := t.Methods[maskedMethodName(t)]; !found {
+			g.doMasked(t, sw)
+		}
+	}
+
+	if g.policy.changedFields.Has(t.Name.String()) {
+		if _, found := t.Methods[changedFieldsMethodName(t)]; !found {
+			g.doChangedFields(t, sw)
+		}
+	}
+
+	if g.policy.mergePatching.Has(t.Name.String()) {
+		if _, found := t.Methods[mergePatchMethodName(t)]; !found {
+			g.doMergePatch(t, sw)
+		}
+	}
+
+	if g.policy.zeroTesting.Has(t.Name.String()) {
+		if _, found := t.Methods[isZeroMethodName(t)]; !found {
+			g.doIsZero(t, sw)
+		}
 	}
 
 	// Create a fake entry for the type we just generated so that it gets
@@ -513,7 +1015,99 @@ func (g *genDeepEqual) GenerateType(c *generator.Context, t *types.Type, w io.Wr
 		t.Methods = make(map[string]*types.Type)
 	}
 
-	t.Methods["DeepEqual"] = &types.Type{
+	t.Methods[deepEqualMethodName(t)] = fakeEqualMethod(t)
+	if g.policy.matching.Has(t.Name.String()) {
+		if _, found := t.Methods[matchesMethodName(t)]; !found {
+			t.Methods[matchesMethodName(t)] = fakeEqualMethod(t)
+		}
+	}
+	for _, profile := range profiles {
+		if _, found := t.Methods[profile.name]; !found {
+			t.Methods[profile.name] = fakeEqualMethod(t)
+		}
+	}
+	return sw.Error()
+}
+
+// doLocked generates a variant of the DeepEqual method of struct type t which
+// holds the lock stored in the named field of both values during the
+// comparison.  Read locks are used if the lock provides them.
+func (g *genDeepEqual) doLocked(t *types.Type, field string, sw *generator.SnippetWriter) {
+	name := deepEqualMethodName(t) + "Locked"
+	if _, found := t.Methods[name]; found {
+		// The author has provided their own implementation.
+		return
+	}
+
+	ut := underlyingType(t)
+	if ut.Kind != types.Struct {
+		klog.Fatalf("Type %v: %s can only be set on struct types", t, tagLockTagName)
+	}
+	var lock *types.Type
+	for _, m := range ut.Members {
+		if m.Name == field {
+			lock = m.Type
+			break
+		}
+	}
+	if lock == nil {
+		klog.Fatalf("Type %v: %s field %q not found", t, tagLockTagName, field)
+	}
+	args := argsFromType(t)
+	args["name"] = name
+	args["method"] = deepEqualMethodName(t)
+	args["field"] = field
+	args["address"] = "&"
+	if ul := underlyingType(lock); ul.Kind == types.Pointer {
+		// Values may share the lock.
+		lock = ul.Elem
+		args["address"] = ""
+	}
+	args["pointer"] = types.Ref("unsafe", "Pointer")
+	_, rlock := lock.Methods["RLock"]
+	_, runlock := lock.Methods["RUnlock"]
+	_, plock := lock.Methods["Lock"]
+	_, punlock := lock.Methods["Unlock"]
+	switch {
+	case rlock && runlock:
+		args["lock"], args["unlock"] = "RLock", "RUnlock"
+	case plock && punlock:
+		args["lock"], args["unlock"] = "Lock", "Unlock"
+	default:
+		klog.Fatalf("Type %v: %s field %q of type %v is not a lock", t, tagLockTagName, field, lock)
+	}
+
+	klog.V(5).Infof("Generating %s function for type %v", name, t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, comparing the\n", args)
+	sw.Do("// receiver with other like $.method$ while holding the lock in the\n", args)
+	sw.Do("// $.field$ field of both. The locks are taken in address order, and a lock\n", args)
+	sw.Do("// shared by both only once, so that concurrent comparisons in both\n", nil)
+	sw.Do("// directions cannot deadlock. in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$(other *$.type|raw$) bool {\n", args)
+	sw.Do("if other == nil {\n", nil)
+	sw.Do("return false\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("first, second := $.address$in.$.field$, $.address$other.$.field$\n", args)
+	sw.Do("if first == second {\n", nil)
+	sw.Do("first.$.lock$()\n", args)
+	sw.Do("defer first.$.unlock$()\n", args)
+	sw.Do("return in.$.method$(other)\n", args)
+	sw.Do("}\n\n", nil)
+	sw.Do("if uintptr($.pointer|raw$(second)) < uintptr($.pointer|raw$(first)) {\n", args)
+	sw.Do("first, second = second, first\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("first.$.lock$()\n", args)
+	sw.Do("defer first.$.unlock$()\n", args)
+	sw.Do("second.$.lock$()\n", args)
+	sw.Do("defer second.$.unlock$()\n\n", args)
+	sw.Do("return in.$.method$(other)\n", args)
+	sw.Do("}\n\n", nil)
+}
+
+// fakeEqualMethod returns the signature of a generated comparison method for
+// type t.
+func fakeEqualMethod(t *types.Type) *types.Type {
+	return &types.Type{
 		Kind: types.Func,
 		Signature: &types.Signature{
 			Receiver: &types.Type{
@@ -528,7 +1122,16 @@ func (g *genDeepEqual) GenerateType(c *generator.Context, t *types.Type, w io.Wr
 			Results: []*types.Type{types.Bool},
 		},
 	}
-	return sw.Error()
+}
+
+// equalMethod returns the name of the method used to compare nested values
+// of type t.  While a profile is being generated, nested types that define
+// the same profile are compared with it; all others fall back to DeepEqual.
+func (g *genDeepEqual) equalMethod(t *types.Type) string {
+	if g.profile != nil && hasProfile(t, g.profile.name) {
+		return g.profile.name
+	}
+	return deepEqualMethodName(t)
 }
 
 // we use the system of shadowing 'in' and 'other' so that the same code is valid
@@ -566,6 +1169,32 @@ func (g *genDeepEqual) generateFor(t *types.Type, sw *generator.SnippetWriter) {
 	}
 }
 
+// doDelegate generates code comparing in and other, pointers to values of
+// type t, with the comparison method of the type.
+func (g *genDeepEqual) doDelegate(t *types.Type, sw *generator.SnippetWriter) {
+	args := generator.Args{
+		"method": g.equalMethod(t),
+		"extra":  "",
+	}
+	if g.visited && g.profile == nil {
+		if method := g.visitedMethod(t); method != "" {
+			// Keep carrying the visited set through nested values.
+			args["method"] = method
+			args["extra"] = ", visited"
+		}
+	}
+	if g.masked && g.profile == nil {
+		if method := g.maskedMethod(t); method != "" {
+			// Apply the mask of the field to nested values.
+			args["method"] = method
+			args["extra"] = ", mask"
+		}
+	}
+	sw.Do("if other == nil || !in.$.method$(other$.extra$) {\n", args)
+	sw.Do("return false\n", nil)
+	sw.Do("}\n", nil)
+}
+
 // doBuiltin generates code for a builtin or an alias to a builtin. The generated code is
 // is the same for both cases, i.e. it's the code for the underlying type.
 func (g *genDeepEqual) doBuiltin(t *types.Type, sw *generator.SnippetWriter) {
@@ -581,9 +1210,7 @@ func (g *genDeepEqual) doMap(t *types.Type, sw *generator.SnippetWriter) {
 	uet := underlyingType(ut.Elem)
 
 	if deepEqualMethodOrDie(t) != nil {
-		sw.Do("if other == nil || !in.DeepEqual(other) {\n", nil)
-		sw.Do("return false\n", nil)
-		sw.Do("}\n", nil)
+		g.doDelegate(t, sw)
 		return
 	} else {
 		sw.Do("if other == nil {\n", nil)
@@ -595,7 +1222,14 @@ func (g *genDeepEqual) doMap(t *types.Type, sw *generator.SnippetWriter) {
 	sw.Do("return false\n", nil)
 	sw.Do("} else {\n", nil)
 
-	sw.Do("for key, inValue := range *in {\n", nil)
+	if g.visited && !uet.IsPrimitive() && uet.Kind != types.Pointer {
+		// The visited set is keyed by the addresses of the values, which
+		// must not be shared between iterations like a range variable.
+		sw.Do("for key := range *in {\n", nil)
+		sw.Do("inValue := (*in)[key]\n", nil)
+	} else {
+		sw.Do("for key, inValue := range *in {\n", nil)
+	}
 	sw.Do("if otherValue, present := (*other)[key]; !present {\n", nil)
 	sw.Do("return false\n", nil)
 	sw.Do("} else {\n", nil)
@@ -605,16 +1239,10 @@ func (g *genDeepEqual) doMap(t *types.Type, sw *generator.SnippetWriter) {
 		if uet.Elem.IsPrimitive() {
 			sw.Do("if ((inValue == nil) != (otherValue == nil) || ((inValue != nil) && (otherValue != nil) && (*inValue != *otherValue))) {\n", nil)
 		} else {
-			sw.Do("if !inValue.DeepEqual(otherValue) {\n", nil)
-		}
-	} else if ut.Elem.Kind != types.Alias && uet.Kind != types.Struct {
-		// TODO(alegacy): for now we do not support generating an inline
-		//  comparison for a complex structure.  The recommended approach is
-		//  to define a type alias and to either manually define a DeepEqual
-		//  method for it or to have one code generated.
-		klog.Fatalf("Hit an unsupported type %v for %v, from %v", uet, ut, t)
+			g.doCompare(uet.Elem, "inValue", "otherValue", true, false, g.path+"[*]", sw)
+		}
 	} else {
-		sw.Do("if !inValue.DeepEqual(&otherValue) {\n", nil)
+		g.doCompare(ut.Elem, "inValue", "otherValue", false, false, g.path+"[*]", sw)
 	}
 	sw.Do("return false\n", nil)
 	sw.Do("}\n", nil)
@@ -630,9 +1258,10 @@ func (g *genDeepEqual) doSlice(t *types.Type, sw *generator.SnippetWriter) {
 	uet := underlyingType(ut.Elem)
 
 	if deepEqualMethodOrDie(t) != nil {
-		sw.Do("if other == nil || !in.DeepEqual(other) {\n", nil)
-		sw.Do("return false\n", nil)
-		sw.Do("}\n", nil)
+		if g.unordered != nil {
+			klog.Fatalf("Type %v: %s can only be set on fields of unnamed slice types, set it on the type instead", t, tagUnorderedArraysTagName)
+		}
+		g.doDelegate(t, sw)
 		return
 	} else {
 		sw.Do("if other == nil {\n", nil)
@@ -641,30 +1270,42 @@ func (g *genDeepEqual) doSlice(t *types.Type, sw *generator.SnippetWriter) {
 	}
 
 	unorderedArrayTag := extractUnorderedArrayTypeTag(t)
+	if g.unordered != nil {
+		// A field level tag overrides the type level tag.
+		unorderedArrayTag = g.unordered
+	}
 
 	sw.Do("if len(*in) != len(*other) {\n", nil)
 	sw.Do("return false\n", nil)
 	sw.Do("} else {\n", nil)
-	if unorderedArrayTag != nil && unorderedArrayTag.value == "true" {
-		sw.Do("for _, inElement := range *in {\n", nil)
-		sw.Do("found := false\n", nil)
-		sw.Do("for _, otherElement := range *other {\n", nil)
+	sorted := g.sortsUnordered(ut.Elem) && !(g.masked && g.maskedElement(t) != "")
+	// The visited set is keyed by the addresses of the values, so elements
+	// are compared in the slices rather than through range variables.
+	indexed := g.visited && !uet.IsPrimitive() && uet.Kind != types.Pointer
+	if unorderedArrayTag != nil && unorderedArrayTag.value == "true" && sorted {
+		g.doSortedSlice(t, sw)
+	} else if unorderedArrayTag != nil && unorderedArrayTag.value == "true" {
+		if indexed {
+			sw.Do("for i := range *in {\n", nil)
+			sw.Do("found := false\n", nil)
+			sw.Do("for j := range *other {\n", nil)
+		} else {
+			sw.Do("for _, inElement := range *in {\n", nil)
+			sw.Do("found := false\n", nil)
+			sw.Do("for _, otherElement := range *other {\n", nil)
+		}
 		if uet.IsPrimitive() {
 			sw.Do("if inElement == otherElement {\n", nil)
 		} else if uet.Kind == types.Pointer {
 			if uet.Elem.IsPrimitive() {
 				sw.Do("if ((inElement == nil) && (otherElement == nil) || ((inElement != nil) && (otherElement != nil) && (*inElement == *otherElement))) {\n", nil)
 			} else {
-				sw.Do("if inElement.DeepEqual(otherElement) {\n", nil)
+				g.doCompare(uet.Elem, "inElement", "otherElement", true, true, g.path+"[*]", sw)
 			}
-		} else if ut.Elem.Kind != types.Alias && uet.Kind != types.Struct {
-			// TODO(alegacy): for now we do not support generating an inline
-			//  comparison for a complex structure.  The recommended approach is
-			//  to define a type alias and to either manually define a DeepEqual
-			//  method for it or to have one code generated.
-			klog.Fatalf("Hit an unsupported type %v for %v, from %v", uet, ut, t)
+		} else if indexed {
+			g.doCompare(ut.Elem, "(*in)[i]", "(*other)[j]", false, true, g.path+"[*]", sw)
 		} else {
-			sw.Do("if inElement.DeepEqual(&otherElement) {\n", nil)
+			g.doCompare(ut.Elem, "inElement", "otherElement", false, true, g.path+"[*]", sw)
 		}
 		sw.Do("found = true\n", nil)
 		sw.Do("break\n", nil)
@@ -675,23 +1316,23 @@ func (g *genDeepEqual) doSlice(t *types.Type, sw *generator.SnippetWriter) {
 		sw.Do("}\n", nil)
 		sw.Do("}\n", nil)
 	} else {
-		sw.Do("for i, inElement := range *in {\n", nil)
+		if indexed {
+			sw.Do("for i := range *in {\n", nil)
+		} else {
+			sw.Do("for i, inElement := range *in {\n", nil)
+		}
 		if uet.IsPrimitive() {
 			sw.Do("if inElement != (*other)[i] {\n", nil)
 		} else if uet.Kind == types.Pointer {
 			if uet.Elem.IsPrimitive() {
 				sw.Do("if ((inElement == nil) && ((*other)[i] == nil) || ((inElement != nil) && ((*other)[i] != nil) && (*inElement != *(*other)[i]))) {\n", nil)
 			} else {
-				sw.Do("if !inElement.DeepEqual((*other)[i]) {\n", nil)
+				g.doCompare(uet.Elem, "inElement", "(*other)[i]", true, false, g.path+"[*]", sw)
 			}
-		} else if ut.Elem.Kind != types.Alias && uet.Kind != types.Struct {
-			// TODO(alegacy): for now we do not support generating an inline
-			//  comparison for a complex structure.  The recommended approach is
-			//  to define a type alias and to either manually define a DeepEqual
-			//  method for it or to have one code generated.
-			klog.Fatalf("Hit an unsupported type %v for %v, from %v", uet, ut, t)
+		} else if indexed {
+			g.doCompare(ut.Elem, "(*in)[i]", "(*other)[i]", false, false, g.path+"[*]", sw)
 		} else {
-			sw.Do("if !inElement.DeepEqual(&(*other)[i]) {\n", nil)
+			g.doCompare(ut.Elem, "inElement", "(*other)[i]", false, false, g.path+"[*]", sw)
 		}
 		sw.Do("return false\n", nil)
 		sw.Do("}\n", nil)
@@ -718,15 +1359,78 @@ func IsComparable(t *types.Type) bool {
 	return false
 }
 
+// delegate describes a nested value whose comparison the generated code
+// delegates to a method of its type rather than performing inline.
+type delegate struct {
+	path string      // Path of the nested value relative to the enclosing type.
+	t    *types.Type // Type providing the comparison method.
+}
+
+// delegates returns the nested values of type t which the generated code
+// compares by calling a method on their type.  It mirrors the decisions made
+// by the do* methods below.
+func delegates(t *types.Type) []delegate {
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Slice, types.Map:
+		return elementDelegates(t, "")
+	case types.Struct:
+		result := []delegate{}
+		for i := range ut.Members {
+			m := &ut.Members[i]
+			if ignoresMember(ut, m) || isLockMember(m) || fieldEncoding(m) != "" {
+				continue
+			}
+			ft := m.Type
+			uft := underlyingType(ft)
+			switch uft.Kind {
+			case types.Pointer:
+				if !underlyingType(uft.Elem).IsPrimitive() {
+					result = append(result, delegate{path: m.Name, t: uft.Elem})
+				}
+			case types.Slice, types.Map:
+				if deepEqualMethodOrDie(ft) != nil {
+					result = append(result, delegate{path: m.Name, t: ft})
+				} else {
+					result = append(result, elementDelegates(ft, m.Name)...)
+				}
+			case types.Struct:
+				if !IsComparable(uft) || isAtomicType(ft) {
+					result = append(result, delegate{path: m.Name, t: ft})
+				}
+			case types.Interface:
+				result = append(result, delegate{path: m.Name, t: ft})
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// elementDelegates returns the elements of slice or map type t which the
+// generated code compares by calling a method on their type.
+func elementDelegates(t *types.Type, path string) []delegate {
+	ut := underlyingType(t)
+	uet := underlyingType(ut.Elem)
+	path = path + "[*]"
+	switch {
+	case uet.IsPrimitive():
+		return nil
+	case uet.Kind == types.Pointer:
+		if uet.Elem.IsPrimitive() {
+			return nil
+		}
+		return []delegate{{path: path, t: uet.Elem}}
+	default:
+		return []delegate{{path: path, t: ut.Elem}}
+	}
+}
+
 // doStruct generates code for a struct or an alias to a struct. The generated code is
 // is the same for both cases, i.e. it's the code for the underlying type.
 func (g *genDeepEqual) doStruct(t *types.Type, sw *generator.SnippetWriter) {
-	ut := underlyingType(t)
-
 	if deepEqualMethodOrDie(t) != nil {
-		sw.Do("if other == nil || !in.DeepEqual(other) {\n", t)
-		sw.Do("return false\n", nil)
-		sw.Do("}\n", nil)
+		g.doDelegate(t, sw)
 		return
 	} else {
 		sw.Do("if other == nil {\n", nil)
@@ -734,67 +1438,303 @@ func (g *genDeepEqual) doStruct(t *types.Type, sw *generator.SnippetWriter) {
 		sw.Do("}\n\n", nil)
 	}
 
+	g.doMembers(t, sw)
+}
+
+// doMembers generates the comparison of the members of struct type t.
+func (g *genDeepEqual) doMembers(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
 	ignoreNilFieldsTag := extractIgnoreNilFieldsTypeTag(ut)
 
-	for _, m := range ut.Members {
-		ft := m.Type
-		uft := underlyingType(ft)
+	for i := range ut.Members {
+		m := &ut.Members[i]
+		if g.profile != nil && g.profile.ignores(m.Name) {
+			klog.V(5).Infof("Not comparing %v.%s in profile %s", t, m.Name, g.profile.name)
+			continue
+		}
+		if g.profile != nil && g.profile.nested(m.Name) != nil {
+			g.doProfileMember(m, g.profile.nested(m.Name), sw)
+			continue
+		}
+		if ignoresMember(ut, m) {
+			klog.V(5).Infof("Not comparing unexported field %v.%s", t, m.Name)
+			continue
+		}
+		if isLockMember(m) {
+			klog.V(2).Infof("Not comparing lock field %v.%s", t, m.Name)
+			continue
+		}
+
+		if g.masked && g.profile == nil {
+			g.doFieldMask(m, sw)
+			g.doMember(t, m, ignoreNilFieldsTag, "", sw)
+			sw.Do("}\n\n", nil)
+		} else {
+			g.doMember(t, m, ignoreNilFieldsTag, "\n", sw)
+		}
+	}
+}
 
-		typeArgs := generator.Args{
-			"type": ft,
-			"kind": ft.Kind,
-			"name": m.Name,
+// doProfileMember generates the comparison of struct member m under profile,
+// which ignores some of its fields, by comparing the members of its struct in
+// place.  The fields its type ignores in the same profile are ignored too.
+func (g *genDeepEqual) doProfileMember(m *types.Member, profile *profileTagValue, sw *generator.SnippetWriter) {
+	for _, own := range extractProfileTypeTags(m.Type) {
+		if own.name != profile.name {
+			continue
 		}
+		for _, name := range own.ignore {
+			path, err := resolveProfileIgnore(m.Type, own.name, name, g.policy)
+			if err != nil {
+				klog.Fatalf("Type %v: %s %q %v", m.Type, tagProfileTagName, own.name, err)
+			}
+			profile.ignore = append(profile.ignore, path)
+		}
+	}
+	compared := false
+	ut := underlyingType(m.Type)
+	for i := range ut.Members {
+		if nm := &ut.Members[i]; !profile.ignores(nm.Name) && !ignoresMember(ut, nm) && !isLockMember(nm) {
+			compared = true
+		}
+	}
+	if !compared {
+		// Every field is ignored.
+		return
+	}
 
-		switch {
-		case uft.Kind == types.Builtin:
-			sw.Do("if in.$.name$ != other.$.name$ {\n", typeArgs)
-			sw.Do("return false\n", nil)
+	outer, path := g.profile, g.path
+	g.profile, g.path = profile, path+"."+m.Name
+	sw.Do("{\n", nil)
+	sw.Do("in, other := &in.$.name$, &other.$.name$\n", generator.Args{"name": m.Name})
+	g.doMembers(m.Type, sw)
+	sw.Do("}\n\n", nil)
+	g.profile, g.path = outer, path
+}
+
+// doMember generates the comparison of member m of struct type t, returning
+// false if the values of in and other differ, followed by separator.
+func (g *genDeepEqual) doMember(t *types.Type, m *types.Member, ignoreNilFieldsTag *enabledTagValue, separator string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	ft := m.Type
+	uft := underlyingType(ft)
+
+	typeArgs := generator.Args{
+		"type":      ft,
+		"kind":      ft.Kind,
+		"name":      m.Name,
+		"method":    g.equalMethod(ft),
+		"separator": separator,
+	}
+
+	if tag := extractNilEqualsEmptyMemberTag(ut, m); tag != nil {
+		// A field level tag overrides the type level tag.
+		typeTag := g.nils
+		g.nils = tag
+		defer func() { g.nils = typeTag }()
+	}
+
+	if fieldEncoding(m) != "" {
+		ignoreNil := ""
+		if uft.Kind == types.Pointer || uft.Kind == types.Slice || uft.Kind == types.Interface {
+			ignoreNil = ignoreNilMode(ignoreNilFieldsTag, ut, m)
+		}
+		switch ignoreNil {
+		case ignoreNilLeft:
+			sw.Do("if in.$.name$ != nil {\n", typeArgs)
+		case ignoreNilEither:
+			sw.Do("if in.$.name$ != nil && other.$.name$ != nil {\n", typeArgs)
+		}
+		g.doEncodedDiffers(m, "in."+m.Name, "other."+m.Name, sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		if ignoreNil != "" {
 			sw.Do("}\n", nil)
+		}
+		sw.Do("$.separator$", typeArgs)
+		return
+	}
 
-		case uft.Kind == types.Pointer:
-			ufet := underlyingType(uft.Elem)
-			if ignoreNilFieldsTag != nil && ignoreNilFieldsTag.value == "true" {
-				// The is some optional attribute that should not be considered
-				// when it is nil.
-				sw.Do("if in.$.name$ != nil {\n", typeArgs)
-			}
+	switch {
+	case uft.Kind == types.Builtin:
+		sw.Do("if in.$.name$ != other.$.name$ {\n", typeArgs)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+
+	case uft.Kind == types.Pointer:
+		ufet := underlyingType(uft.Elem)
+		ignoreNil := ignoreNilMode(ignoreNilFieldsTag, ut, m)
+		switch ignoreNil {
+		case ignoreNilLeft:
+			// The is some optional attribute that should not be considered
+			// when it is nil.
+			sw.Do("if in.$.name$ != nil {\n", typeArgs)
+		case ignoreNilEither:
+			sw.Do("if in.$.name$ != nil && other.$.name$ != nil {\n", typeArgs)
+		}
+		sw.Do("if (in.$.name$ == nil) != (other.$.name$ == nil) {\n", typeArgs)
+		sw.Do("return false\n", nil)
+		sw.Do("} else if in.$.name$ != nil {\n", typeArgs)
+		if ufet.IsPrimitive() {
+			sw.Do("if *in.$.name$ != *other.$.name$ {\n", typeArgs)
+		} else {
+			g.doCompare(uft.Elem, "in."+m.Name, "other."+m.Name, true, false, g.path+"."+m.Name, sw)
+		}
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+		if ignoreNil != "" {
+			sw.Do("}\n", nil)
+		}
+		sw.Do("$.separator$", typeArgs)
+
+	case uft.Kind == types.Slice, uft.Kind == types.Map:
+		ignoreNil := ignoreNilMode(ignoreNilFieldsTag, ut, m)
+		if ignoreNil == "" && distinguishesNil(ut, m) {
+			// Nil is encoded as null, and empty values as [] or {}.
 			sw.Do("if (in.$.name$ == nil) != (other.$.name$ == nil) {\n", typeArgs)
 			sw.Do("return false\n", nil)
-			sw.Do("} else if in.$.name$ != nil {\n", typeArgs)
-			if ufet.IsPrimitive() {
-				sw.Do("if *in.$.name$ != *other.$.name$ {\n", typeArgs)
-			} else {
-				sw.Do("if !in.$.name$.DeepEqual(other.$.name$) {\n", typeArgs)
-			}
-			sw.Do("return false\n", nil)
 			sw.Do("}\n", nil)
-			sw.Do("}\n", nil)
-			if ignoreNilFieldsTag != nil && ignoreNilFieldsTag.value == "true" {
-				sw.Do("}\n", nil)
-			}
-			sw.Do("\n", nil)
-
-		case uft.Kind == types.Slice, uft.Kind == types.Map:
+		}
+		if ignoreNil == ignoreNilEither {
+			sw.Do("if in.$.name$ != nil && other.$.name$ != nil {\n", typeArgs)
+		} else {
 			sw.Do("if ((in.$.name$ != nil) && (other.$.name$ != nil)) ||", typeArgs)
 			sw.Do("((in.$.name$ == nil) != (other.$.name$ == nil)) {\n", typeArgs)
-			sw.Do("in, other := &in.$.name$, &other.$.name$\n", typeArgs)
-			g.generateFor(ft, sw)
-			sw.Do("}\n\n", nil)
+		}
+		sw.Do("in, other := &in.$.name$, &other.$.name$\n", typeArgs)
+		if uft.Kind == types.Slice {
+			g.unordered = extractUnorderedArrayMemberTag(ut, m)
+			if g.unordered == nil && ft.Name.Package == "" {
+				// Unnamed slices follow the default of the package
+				// declaring the struct.
+				g.unordered = extractUnorderedArrayTag(typePackageComments(ut))
+			}
+		}
+		path := g.path
+		g.path = path + "." + m.Name
+		g.generateFor(ft, sw)
+		g.path = path
+		g.unordered = nil
+		sw.Do("}\n$.separator$", typeArgs)
+
+	case uft.Kind == types.Struct:
+		if IsComparable(uft) && typeArgs["method"] == deepEqualMethodName(ft) && !isAtomicType(ft) && !(g.masked && g.profile == nil && g.maskedMethod(ft) != "") {
+			sw.Do("if in.$.name$ != other.$.name$ {\n", typeArgs)
+		} else {
+			g.doCompare(ft, "in."+m.Name, "other."+m.Name, false, false, g.path+"."+m.Name, sw)
+		}
+		sw.Do("return false\n", nil)
+		sw.Do("}\n$.separator$", typeArgs)
+
+	case uft.Kind == types.Interface:
+		ignoreNil := ignoreNilMode(ignoreNilFieldsTag, ut, m)
+		if ignoreNil == ignoreNilEither {
+			sw.Do("if in.$.name$ != nil && other.$.name$ != nil {\n", typeArgs)
+		}
+		// The dynamic type of the value is only known at runtime.
+		g.doCompare(ft, "in."+m.Name, "other."+m.Name, false, false, g.path+"."+m.Name, sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		if ignoreNil == ignoreNilEither {
+			sw.Do("}\n", nil)
+		}
+		sw.Do("$.separator$", typeArgs)
+
+	default:
+		klog.Fatalf("Hit an unsupported type %v for %v, from %v", uft, ft, t)
+	}
+}
+
+// doCompare generates the condition of an if statement comparing the nested
+// values in and other of type t, or pointers to them if pointers is set.  The
+// condition holds when the values are equal if equal is set, and when they
+// differ otherwise.
+func (g *genDeepEqual) doCompare(t *types.Type, in, other string, pointers, equal bool, path string, sw *generator.SnippetWriter) {
+	c, err := g.policy.chooseAt(t, path)
+	if err != nil {
+		klog.Fatalf("%s: %v", path, err)
+	}
+	if g.profile == nil {
+		if c == compareReflect {
+			klog.Infof("%s: comparing type %v with %v", path, t, c)
+		} else {
+			klog.V(3).Infof("%s: comparing type %v with %v", path, t, c)
+		}
+	}
+
+	args := generator.Args{
+		"in":      in,
+		"other":   other,
+		"method":  g.equalMethod(t),
+		"not":     "!",
+		"op":      "!=",
+		"reflect": types.Ref("reflect", "DeepEqual"),
+	}
+	if c == compareUnstructured {
+		args["unstructured"] = types.Ref(runtimePackage, "UnstructuredEqual")
+		args["nils"] = types.Ref(runtimePackage, "NilDiffersFromEmpty")
+		if g.nils != nil && g.nils.value == "true" {
+			args["nils"] = types.Ref(runtimePackage, "NilEqualsEmpty")
+		}
+	}
+	if equal {
+		args["not"] = ""
+		args["op"] = "=="
+	}
 
-		case uft.Kind == types.Struct:
-			if IsComparable(uft) {
-				sw.Do("if in.$.name$ != other.$.name$ {\n", typeArgs)
+	if g.visited && g.profile == nil {
+		if method := g.visitedMethod(t); method != "" && (c == compareMethod || c == compareGenerated) {
+			// Keep carrying the visited set through nested values.
+			args["method"] = method
+			if pointers {
+				sw.Do("if $.not$$.in$.$.method$($.other$, visited) {\n", args)
 			} else {
-				sw.Do("if !in.$.name$.DeepEqual(&other.$.name$) {\n", typeArgs)
+				sw.Do("if $.not$$.in$.$.method$(&$.other$, visited) {\n", args)
 			}
-			sw.Do("return false\n", nil)
-			sw.Do("}\n\n", nil)
+			return
+		}
+	}
 
-		case uft.Kind == types.Interface:
-			fallthrough
-		default:
-			klog.Fatalf("Hit an unsupported type %v for %v, from %v", uft, ft, t)
+	if g.masked && g.profile == nil {
+		if method := g.maskedMethod(t); method != "" && (c == compareMethod || c == compareGenerated) {
+			// Apply the mask of the field to nested values.
+			args["method"] = method
+			if pointers {
+				sw.Do("if $.not$$.in$.$.method$($.other$, mask) {\n", args)
+			} else {
+				sw.Do("if $.not$$.in$.$.method$(&$.other$, mask) {\n", args)
+			}
+			return
+		}
+	}
+
+	switch c {
+	case compareMethod, compareGenerated:
+		if pointers {
+			sw.Do("if $.not$$.in$.$.method$($.other$) {\n", args)
+		} else {
+			sw.Do("if $.not$$.in$.$.method$(&$.other$) {\n", args)
+		}
+	case compareOperator:
+		if pointers {
+			sw.Do("if *$.in$ $.op$ *$.other$ {\n", args)
+		} else {
+			sw.Do("if $.in$ $.op$ $.other$ {\n", args)
+		}
+	case compareReflect:
+		sw.Do("if $.not$$.reflect|raw$($.in$, $.other$) {\n", args)
+	case compareUnstructured:
+		if pointers {
+			sw.Do("if $.not$$.unstructured|raw$(*$.in$, *$.other$, $.nils|raw$) {\n", args)
+		} else {
+			sw.Do("if $.not$$.unstructured|raw$($.in$, $.other$, $.nils|raw$) {\n", args)
+		}
+	case compareAtomic:
+		if t.Name.Name == "Value" {
+			sw.Do("if $.not$$.reflect|raw$($.in$.Load(), $.other$.Load()) {\n", args)
+		} else {
+			sw.Do("if $.in$.Load() $.op$ $.other$.Load() {\n", args)
 		}
 	}
 }
@@ -7,8 +7,11 @@ Copyright 2019 Wind River Systems, Inc.
 package generators
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	"k8s.io/gengo/examples/set-gen/sets"
 	"k8s.io/gengo/types"
 )
 
@@ -376,3 +379,131 @@ func Test_extractTagParams(t *testing.T) {
 		}
 	}
 }
+
+func Test_extractProfileTags(t *testing.T) {
+	testCases := []struct {
+		comments []string
+		expect   []*profileTagValue
+	}{
+		{
+			comments: []string{
+				"Human comment",
+			},
+			expect: nil,
+		},
+		{
+			comments: []string{
+				"Human comment",
+				"+deepequal-gen:profile=SpecEqual",
+			},
+			expect: []*profileTagValue{
+				{name: "SpecEqual"},
+			},
+		},
+		{
+			comments: []string{
+				"Human comment",
+				"+deepequal-gen:profile=SpecEqual,ignore=Status;ResourceVersion",
+				"+deepequal-gen:profile=IgnoreTimestamps,ignore=CreationTimestamp",
+			},
+			expect: []*profileTagValue{
+				{name: "SpecEqual", ignore: []string{"Status", "ResourceVersion"}},
+				{name: "IgnoreTimestamps", ignore: []string{"CreationTimestamp"}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		r := extractProfileTags(tc.comments)
+		if !reflect.DeepEqual(r, tc.expect) {
+			t.Errorf("case[%d]: expected %v, got %v", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_extractSingleValueTag(t *testing.T) {
+	testCases := []struct {
+		comments []string
+		expect   *enabledTagValue
+	}{
+		{
+			comments: []string{
+				"Human comment",
+			},
+			expect: nil,
+		},
+		{
+			comments: []string{
+				"Human comment",
+				"+deepequal-gen:ignore-unexported-fields=true",
+			},
+			expect: &enabledTagValue{value: "true"},
+		},
+		{
+			comments: []string{
+				"+deepequal-gen:ignore-unexported-fields",
+			},
+			expect: &enabledTagValue{value: ""},
+		},
+	}
+
+	for i, tc := range testCases {
+		r := extractSingleValueTag(tagIgnoreUnexportedName, tc.comments)
+		if !reflect.DeepEqual(r, tc.expect) {
+			t.Errorf("case[%d]: expected %v, got %v", i, tc.expect, r)
+		}
+	}
+}
+
+func Test_resolveProfileIgnore(t *testing.T) {
+	str := types.String
+	meta := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "ObjectMeta"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+			{Name: "ResourceVersion", Type: str},
+		},
+	}
+	other := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "OtherMeta"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	object := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Object"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "ObjectMeta", Type: meta, Embedded: true},
+			{Name: "OtherMeta", Type: other, Embedded: true},
+			{Name: "Status", Type: str},
+		},
+	}
+	policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+	policy.generating.Insert(meta.Name.String(), other.Name.String(), object.Name.String())
+
+	testCases := []struct {
+		name   string
+		expect string
+		err    string
+	}{
+		{name: "Status", expect: "Status"},
+		{name: "ResourceVersion", expect: "ObjectMeta.ResourceVersion"},
+		{name: "ObjectMeta.ResourceVersion", expect: "ObjectMeta.ResourceVersion"},
+		{name: "OtherMeta.Name", expect: "OtherMeta.Name"},
+		{name: "Name", err: `ignores ambiguous field "Name", name it by its path`},
+		{name: "Generation", err: `ignores unknown field "Generation"`},
+		{name: "Status.Ready", err: `cannot ignore "Status.Ready": string is not a struct`},
+	}
+	for i, tc := range testCases {
+		path, err := resolveProfileIgnore(object, "SpecEqual", tc.name, policy)
+		switch {
+		case tc.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.err)):
+			t.Errorf("case[%d]: expected error %q, got %v", i, tc.err, err)
+		case tc.err == "" && (err != nil || path != tc.expect):
+			t.Errorf("case[%d]: expected %q, got %q, %v", i, tc.expect, path, err)
+		}
+	}
+}
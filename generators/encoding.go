@@ -0,0 +1,124 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// fieldTagName is the key of the struct field tag comparing a field by its
+// encoding, e.g. `deepequal:"json"`.
+const fieldTagName = "deepequal"
+
+// Known values for the fieldTagName struct field tag.
+const (
+	// encodingJSON compares string and []byte fields holding JSON documents.
+	encodingJSON = "json"
+	// encodingYAML compares string and []byte fields holding YAML documents.
+	encodingYAML = "yaml"
+	// encodingText compares fields implementing encoding.TextMarshaler.
+	encodingText = "text"
+)
+
+// fieldEncoding returns the encoding member m is compared by, or an empty
+// string if it is compared by value.
+func fieldEncoding(m *types.Member) string {
+	return reflect.StructTag(m.Tags).Get(fieldTagName)
+}
+
+// hasTextMarshaler returns whether values of type t, or the values t points
+// to, implement encoding.TextMarshaler.
+func hasTextMarshaler(t *types.Type) bool {
+	if _, found := t.Methods["MarshalText"]; found {
+		return true
+	}
+	ut := underlyingType(t)
+	if _, found := ut.Methods["MarshalText"]; found {
+		return true
+	}
+	return ut.Kind == types.Pointer && hasTextMarshaler(ut.Elem)
+}
+
+// checkFieldEncoding returns an error if member m cannot be compared by the
+// encoding set by its struct tag.
+func checkFieldEncoding(m *types.Member, encoding string) error {
+	uft := underlyingType(m.Type)
+	if uft.Kind == types.Unsupported || uft.Kind == types.Unknown {
+		// Types which could not be loaded are left to the compiler.
+		return nil
+	}
+	switch encoding {
+	case encodingJSON, encodingYAML:
+		if uft.Kind == types.Builtin && uft.Name.Name == "string" {
+			return nil
+		}
+		if uft.Kind == types.Slice {
+			if uet := underlyingType(uft.Elem); uet.Kind == types.Builtin && (uet.Name.Name == "byte" || uet.Name.Name == "uint8") {
+				return nil
+			}
+		}
+		return fmt.Errorf("struct tag %s:%q is only supported on string and []byte fields", fieldTagName, encoding)
+	case encodingText:
+		if hasTextMarshaler(m.Type) {
+			return nil
+		}
+		return fmt.Errorf("struct tag %s:%q requires a field implementing encoding.TextMarshaler", fieldTagName, encoding)
+	}
+	return fmt.Errorf("struct tag %s:%q has unsupported value, expected %q, %q or %q", fieldTagName, encoding, encodingJSON, encodingYAML, encodingText)
+}
+
+// doEncodedDiffers generates the opening of an if statement whose body runs
+// when the values in and other of member m differ in the encoding set by its
+// struct tag.
+func (g *genDeepEqual) doEncodedDiffers(m *types.Member, in, other string, sw *generator.SnippetWriter) {
+	uft := underlyingType(m.Type)
+	args := generator.Args{
+		"in":    in,
+		"other": other,
+		"equal": types.Ref(runtimePackage, "TextEqual"),
+	}
+	switch encoding := fieldEncoding(m); {
+	case encoding != encodingText:
+		if encoding == encodingJSON {
+			args["equal"] = types.Ref(runtimePackage, "JSONEqual")
+		} else {
+			args["equal"] = types.Ref(runtimePackage, "YAMLEqual")
+		}
+		if uft.Kind == types.Builtin {
+			sw.Do("if !$.equal|raw$([]byte($.in$), []byte($.other$)) {\n", args)
+		} else {
+			sw.Do("if !$.equal|raw$($.in$, $.other$) {\n", args)
+		}
+	case uft.Kind == types.Pointer, uft.Kind == types.Interface:
+		sw.Do("if ($.in$ == nil) != ($.other$ == nil) || ($.in$ != nil && !$.equal|raw$($.in$, $.other$)) {\n", args)
+	default:
+		// The method set of a pointer holds the methods of both receivers.
+		sw.Do("if !$.equal|raw$(&$.in$, &$.other$) {\n", args)
+	}
+}
+
+// doEncodedNonZero generates the opening of an if statement whose body runs
+// when the value in of member m, compared by its encoding, is not zero.
+func (g *genDeepEqual) doEncodedNonZero(m *types.Member, in string, sw *generator.SnippetWriter) {
+	uft := underlyingType(m.Type)
+	args := generator.Args{
+		"in":    in,
+		"type":  m.Type,
+		"equal": types.Ref(runtimePackage, "TextEqual"),
+	}
+	switch {
+	case fieldEncoding(m) != encodingText:
+		sw.Do("if len($.in$) != 0 {\n", args)
+	case uft.Kind == types.Pointer, uft.Kind == types.Interface:
+		sw.Do("if $.in$ != nil {\n", args)
+	default:
+		sw.Do("if !$.equal|raw$(new($.type|raw$), &$.in$) {\n", args)
+	}
+}
@@ -0,0 +1,61 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_checkFieldEncoding(t *testing.T) {
+	bytes := &types.Type{Kind: types.Slice, Elem: types.Byte}
+	raw := &types.Type{
+		Name:       types.Name{Package: "example.com/api", Name: "Raw"},
+		Kind:       types.Alias,
+		Underlying: bytes,
+	}
+	marshaler := &types.Type{
+		Name:    types.Name{Package: "example.com/api", Name: "Version"},
+		Kind:    types.Struct,
+		Methods: map[string]*types.Type{"MarshalText": {Kind: types.Func}},
+	}
+	plain := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Plain"},
+		Kind: types.Struct,
+	}
+	unloaded := &types.Type{
+		Name: types.Name{Package: "encoding/json", Name: "RawMessage"},
+		Kind: types.Unsupported,
+	}
+
+	testCases := []struct {
+		typ      *types.Type
+		encoding string
+		expect   string
+	}{
+		{types.String, "json", ""},
+		{bytes, "yaml", ""},
+		{raw, "json", ""},
+		{unloaded, "json", ""},
+		{types.Int, "json", `struct tag deepequal:"json" is only supported on string and []byte fields`},
+		{&types.Type{Kind: types.Slice, Elem: types.String}, "yaml", `struct tag deepequal:"yaml" is only supported on string and []byte fields`},
+		{marshaler, "text", ""},
+		{&types.Type{Kind: types.Pointer, Elem: marshaler}, "text", ""},
+		{plain, "text", `struct tag deepequal:"text" requires a field implementing encoding.TextMarshaler`},
+		{types.String, "xml", `struct tag deepequal:"xml" has unsupported value, expected "json", "yaml" or "text"`},
+	}
+	for i, tc := range testCases {
+		err := checkFieldEncoding(&types.Member{Name: "Field", Type: tc.typ}, tc.encoding)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, got)
+		}
+	}
+}
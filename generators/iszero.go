@@ -0,0 +1,172 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// isZeroMethodName returns the name of the DeepIsZero method of type t.
+func isZeroMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepIsZero"
+	}
+	return "DeepIsZero"
+}
+
+// zeroTestingTypes returns the types which get a DeepIsZero method generated:
+// the generated types which opted in with the is-zero tag, and the generated
+// structs held by their fields which cannot be compared with ==, so that
+// nested structs are tested field by field.  An error is returned for every
+// opted in type which cannot have the method.
+func zeroTestingTypes(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	zeroTesting := &optIn{
+		tag:         tagIsZeroTagName,
+		eligible:    fieldwiseType,
+		requirement: "a struct, slice or map type",
+		nested:      zeroTestedStructs,
+	}
+	return zeroTesting.types(generated, policy)
+}
+
+// zeroTestedStructs returns the struct types held by the fields of struct type
+// t which are tested field by field: the structs which cannot be compared
+// with ==, unless the field is compared by its encoding.
+func zeroTestedStructs(t *types.Type) []*types.Type {
+	var result []*types.Type
+	for _, m := range comparedMembers(t) {
+		unt := underlyingType(m.Type)
+		if fieldEncoding(m) != "" || unt.Kind != types.Struct || IsComparable(unt) {
+			continue
+		}
+		result = append(result, m.Type)
+	}
+	return result
+}
+
+// isZeroMethod returns the name of the DeepIsZero method of type t, or an
+// empty string if it has none.
+func (g *genDeepEqual) isZeroMethod(t *types.Type) string {
+	name := isZeroMethodName(t)
+	if g.policy.zeroTesting.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	if g.policy.hasGeneratedMethod(t, name) {
+		return name
+	}
+	return ""
+}
+
+// doIsZero generates the DeepIsZero method of type t.
+func (g *genDeepEqual) doIsZero(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["name"] = isZeroMethodName(t)
+	args["method"] = deepEqualMethodName(t)
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, reporting whether the\n", args)
+	sw.Do("// receiver is equal to the zero value of its type by $.method$.  Fields\n", args)
+	sw.Do("// which $.method$ skips when they are nil are only zero when nil.  in must be\n", args)
+	sw.Do("// non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$() bool {\n", args)
+
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Struct:
+		ignoreNilFieldsTag := extractIgnoreNilFieldsTypeTag(ut)
+		for _, m := range comparedMembers(t) {
+			g.doMemberNonZero(t, m, ignoreNilFieldsTag, sw)
+			sw.Do("return false\n", nil)
+			sw.Do("}\n", nil)
+		}
+		sw.Do("\nreturn true\n", nil)
+	case types.Slice, types.Map:
+		sw.Do("return len(*in) == 0\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+}
+
+// doMemberNonZero generates the opening of an if statement whose body runs
+// when member m of struct type t is not equal to its zero value by the
+// DeepEqual method of t.
+func (g *genDeepEqual) doMemberNonZero(t *types.Type, m *types.Member, ignoreNilFieldsTag *enabledTagValue, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	ft := m.Type
+	uft := underlyingType(ft)
+	args := generator.Args{
+		"name":   m.Name,
+		"type":   ft,
+		"method": g.isZeroMethod(ft),
+	}
+
+	if fieldEncoding(m) != "" {
+		g.doEncodedNonZero(m, "in."+m.Name, sw)
+		return
+	}
+
+	switch {
+	case uft.Kind == types.Builtin && uft.Name.Name == "bool":
+		sw.Do("if in.$.name$ {\n", args)
+
+	case uft.Kind == types.Builtin:
+		args["zero"] = zeroLiteral(uft)
+		sw.Do("if in.$.name$ != $.zero$ {\n", args)
+
+	case uft.Kind == types.Pointer:
+		// Nil and non-nil pointers always differ, and pointers skipped when
+		// nil are set otherwise.
+		sw.Do("if in.$.name$ != nil {\n", args)
+
+	case uft.Kind == types.Slice, uft.Kind == types.Map:
+		if ignoreNilMode(ignoreNilFieldsTag, ut, m) != "" || distinguishesNil(ut, m) {
+			// Empty values are set, or differ from nil values.
+			sw.Do("if in.$.name$ != nil {\n", args)
+		} else if args["method"] != "" {
+			sw.Do("if !in.$.name$.$.method$() {\n", args)
+		} else {
+			sw.Do("if len(in.$.name$) != 0 {\n", args)
+		}
+
+	case uft.Kind == types.Interface:
+		sw.Do("if in.$.name$ != nil {\n", args)
+
+	case IsComparable(uft) && !isAtomicType(ft):
+		sw.Do("if in.$.name$ != ($.type|raw${}) {\n", args)
+
+	case args["method"] != "":
+		sw.Do("if !in.$.name$.$.method$() {\n", args)
+
+	case isAtomicType(ft):
+		switch ft.Name.Name {
+		case "Bool":
+			sw.Do("if in.$.name$.Load() {\n", args)
+		case "Value":
+			sw.Do("if in.$.name$.Load() != nil {\n", args)
+		default:
+			sw.Do("if in.$.name$.Load() != 0 {\n", args)
+		}
+
+	default:
+		c, err := g.policy.choose(ft)
+		if err != nil {
+			klog.Fatalf("%s.%s: %v", t, m.Name, err)
+		}
+		if c == compareMethod || c == compareGenerated {
+			args["method"] = deepEqualMethodName(ft)
+			sw.Do("if !in.$.name$.$.method$(&$.type|raw${}) {\n", args)
+		} else {
+			args["valueOf"] = types.Ref("reflect", "ValueOf")
+			sw.Do("if !$.valueOf|raw$(&in.$.name$).Elem().IsZero() {\n", args)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+func Test_zeroTestingTypes(t *testing.T) {
+	newStruct := func(name string, comments ...string) *types.Type {
+		return &types.Type{
+			Name:         types.Name{Package: "example.com/api", Name: name},
+			Kind:         types.Struct,
+			CommentLines: comments,
+			Members:      []types.Member{{Name: "Values", Type: &types.Type{Kind: types.Slice, Elem: types.String}}},
+		}
+	}
+	point := newStruct("Point")
+	point.Members = []types.Member{{Name: "X", Type: types.Int}}
+	spec := newStruct("Spec")
+	status := newStruct("Status")
+	meta := newStruct("Meta")
+	root := newStruct("Root", "+deepequal-gen:is-zero=true")
+	root.Members = []types.Member{
+		{Name: "Point", Type: point},
+		{Name: "Spec", Type: spec},
+		{Name: "Status", Type: &types.Type{Kind: types.Pointer, Elem: status}},
+		{Name: "Meta", Type: meta, Tags: `deepequal:"json"`},
+	}
+	name := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Name"},
+		Kind:         types.Alias,
+		Underlying:   types.String,
+		CommentLines: []string{"+deepequal-gen:is-zero=true"},
+	}
+	list := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "List"},
+		Kind:         types.Slice,
+		Elem:         types.String,
+		CommentLines: []string{"+deepequal-gen:is-zero=true"},
+	}
+
+	testCases := []struct {
+		name      string
+		generated []*types.Type
+		expect    []string
+		errs      []string
+	}{
+		{
+			// Comparable structs are compared with their zero value,
+			// pointers are zero when nil, and encoded fields are tested
+			// by their encoding.
+			name:      "nested structs",
+			generated: []*types.Type{meta, point, root, spec, status},
+			expect:    []string{"example.com/api.Root", "example.com/api.Spec"},
+		},
+		{
+			name:      "slices",
+			generated: []*types.Type{list},
+			expect:    []string{"example.com/api.List"},
+		},
+		{
+			name:      "not a struct, slice or map",
+			generated: []*types.Type{name},
+			errs:      []string{"type example.com/api.Name: deepequal-gen:is-zero requires a struct, slice or map type with a generated DeepEqual method"},
+		},
+	}
+
+	for _, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+		for _, t := range tc.generated {
+			policy.generating.Insert(t.Name.String())
+		}
+		zeroTesting, errs := zeroTestingTypes(tc.generated, policy)
+		if strings.Join(zeroTesting.List(), ",") != strings.Join(tc.expect, ",") {
+			t.Errorf("%s: expected zero testing types %v, got %v", tc.name, tc.expect, zeroTesting.List())
+		}
+		if got := strings.Join(errs2strings(errs), "\n"); got != strings.Join(tc.errs, "\n") {
+			t.Errorf("%s: expected errors %q, got %q", tc.name, tc.errs, got)
+		}
+	}
+}
+
+func Test_doIsZero(t *testing.T) {
+	atomicType := func(name string, members ...types.Member) *types.Type {
+		return &types.Type{
+			Name:    types.Name{Package: "sync/atomic", Name: name},
+			Kind:    types.Struct,
+			Members: members,
+		}
+	}
+	mutex := &types.Type{
+		Name:    types.Name{Package: "sync", Name: "Mutex"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "state", Type: types.Int32}},
+	}
+
+	testCases := []struct {
+		name   string
+		member types.Member
+		expect string
+	}{
+		{
+			name:   "atomic integer",
+			member: types.Member{Name: "Count", Type: atomicType("Int64", types.Member{Name: "v", Type: types.Int64})},
+			expect: "if in.Count.Load() != 0 {",
+		},
+		{
+			name:   "atomic bool",
+			member: types.Member{Name: "Ready", Type: atomicType("Bool", types.Member{Name: "v", Type: types.Uint32})},
+			expect: "if in.Ready.Load() {",
+		},
+		{
+			name:   "atomic value",
+			member: types.Member{Name: "Config", Type: atomicType("Value", types.Member{Name: "v", Type: &types.Type{Kind: types.Interface}})},
+			expect: "if in.Config.Load() != nil {",
+		},
+		{
+			// Locks are not part of the value.
+			name:   "lock",
+			member: types.Member{Name: "mu", Type: mutex},
+		},
+		{
+			name:   "pointer to a lock",
+			member: types.Member{Name: "mu", Type: &types.Type{Kind: types.Pointer, Elem: mutex}},
+		},
+	}
+
+	for _, tc := range testCases {
+		counter := &types.Type{
+			Name:    types.Name{Package: "example.com/api", Name: "Counter"},
+			Kind:    types.Struct,
+			Members: []types.Member{tc.member},
+		}
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString("example.com/api"), "ignore_autogenerated")
+		policy.generated["sync/atomic"] = sets.NewString()
+		policy.zeroTesting.Insert(counter.Name.String())
+		g := &genDeepEqual{policy: policy}
+		out := &bytes.Buffer{}
+		c := &generator.Context{Namers: namer.NameSystems{"raw": namer.NewRawNamer("example.com/api", nil)}}
+		sw := generator.NewSnippetWriter(out, c, "$", "$")
+		g.doIsZero(counter, sw)
+		if err := sw.Error(); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		got := out.String()
+		if tc.expect == "" {
+			if strings.Contains(got, "in."+tc.member.Name) {
+				t.Errorf("%s: expected the field to be skipped, got:\n%s", tc.name, got)
+			}
+		} else if !strings.Contains(got, tc.expect) {
+			t.Errorf("%s: expected %q, got:\n%s", tc.name, tc.expect, got)
+		}
+	}
+}
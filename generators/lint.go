@@ -0,0 +1,304 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+// k8sTagPrefix is the prefix of the alias of every tag used by the Kubernetes
+// generators, e.g. "+k8s:deepequal-gen=package".
+const k8sTagPrefix = "+k8s:"
+
+// tagPlace is a set of places where a tag may be written.
+type tagPlace int
+
+const (
+	placePackage tagPlace = 1 << iota
+	placeType
+	placeMember
+)
+
+func (p tagPlace) String() string {
+	switch p {
+	case placePackage:
+		return "package"
+	case placeType:
+		return "type"
+	case placeMember:
+		return "field"
+	}
+	return fmt.Sprintf("tagPlace(%d)", int(p))
+}
+
+// knownTags lists the places where each tag may be written.
+var knownTags = map[string]tagPlace{
+	tagEnabledName:            placePackage | placeType,
+	tagUnorderedArraysTagName: placePackage | placeType | placeMember,
+	tagIgnoreNilFieldsTagName: placePackage | placeType | placeMember,
+	tagIgnoreUnexportedName:   placePackage | placeType,
+	tagProfileTagName:         placeType,
+	tagLockTagName:            placeType,
+	tagMatchesTagName:         placePackage | placeType,
+	tagCycleSafeTagName:       placePackage | placeType,
+	tagCompareTagName:         placePackage | placeType,
+	tagNormalizeTagName:       placePackage | placeType,
+	tagFieldMaskTagName:       placePackage | placeType,
+	tagChangedFieldsTagName:   placePackage | placeType,
+	tagMergePatchTagName:      placePackage | placeType,
+	tagSemanticsTagName:       placePackage | placeType,
+	tagNilEqualsEmptyTagName:  placePackage | placeType | placeMember,
+	tagIsZeroTagName:          placePackage | placeType,
+}
+
+// canonicalTags rewrites the tags written with the k8sTagPrefix alias to
+// their canonical form.
+func canonicalTags(lines []string) []string {
+	var result []string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, k8sTagPrefix+tagEnabledName) {
+			continue
+		}
+		if result == nil {
+			result = append([]string{}, lines...)
+		}
+		result[i] = "+" + strings.TrimPrefix(trimmed, k8sTagPrefix)
+	}
+	if result == nil {
+		return lines
+	}
+	return result
+}
+
+// lintTags checks the tags of the input packages, their types and the fields
+// of their types, returning an error for every unknown, misplaced or invalid
+// tag.
+func lintTags(universe types.Universe, inputs []string) []error {
+	var errs []error
+	for _, path := range inputs {
+		pkg := universe[path]
+		if pkg == nil {
+			continue
+		}
+		errs = append(errs, lintLines(fmt.Sprintf("package %s", pkg.Path), placePackage, packageHeaderComments(pkg))...)
+
+		names := make([]string, 0, len(pkg.Types))
+		for name := range pkg.Types {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			t := pkg.Types[name]
+			errs = append(errs, lintType(t)...)
+		}
+	}
+	return errs
+}
+
+// lintType checks the tags of type t and of its fields.
+func lintType(t *types.Type) []error {
+	where := fmt.Sprintf("type %v", t)
+	comments := typeComments(t)
+	errs := lintLines(where, placeType, comments)
+
+	ut := underlyingType(t)
+	tags := types.ExtractCommentTags("+", comments)
+	for _, name := range []string{tagIgnoreNilFieldsTagName, tagIgnoreUnexportedName, tagProfileTagName, tagLockTagName, tagChangedFieldsTagName, tagMergePatchTagName, tagSemanticsTagName} {
+		if _, found := tags[name]; found && ut.Kind != types.Struct {
+			errs = append(errs, fmt.Errorf("%s: +%s is only supported on struct types", where, name))
+		}
+	}
+	if _, found := tags[tagUnorderedArraysTagName]; found && ut.Kind != types.Slice {
+		errs = append(errs, fmt.Errorf("%s: +%s is only supported on slice types", where, tagUnorderedArraysTagName))
+	}
+	for _, name := range []string{tagMatchesTagName, tagCompareTagName, tagNormalizeTagName, tagFieldMaskTagName, tagIsZeroTagName} {
+		if _, found := tags[name]; found && ut.Kind != types.Struct && ut.Kind != types.Slice && ut.Kind != types.Map {
+			errs = append(errs, fmt.Errorf("%s: +%s is only supported on struct, slice and map types", where, name))
+		}
+	}
+	if values, found := tags[tagEnabledName]; found && len(values) == 1 && strings.HasPrefix(values[0], tagValuePackage) {
+		errs = append(errs, fmt.Errorf("%s: +%s=%s must be set in the package comments of doc.go", where, tagEnabledName, tagValuePackage))
+	}
+
+	if ut.Kind != types.Struct {
+		return errs
+	}
+	for i := range ut.Members {
+		m := &ut.Members[i]
+		where := fmt.Sprintf("field %v.%s", t, m.Name)
+		comments := memberComments(ut, m)
+		errs = append(errs, lintLines(where, placeMember, comments)...)
+
+		uft := underlyingType(m.Type)
+		tags := types.ExtractCommentTags("+", comments)
+		if _, found := tags[tagUnorderedArraysTagName]; found && uft.Kind != types.Slice {
+			errs = append(errs, fmt.Errorf("%s: +%s is only supported on slice fields", where, tagUnorderedArraysTagName))
+		}
+		if encoding, found := reflect.StructTag(m.Tags).Lookup(fieldTagName); found {
+			if err := checkFieldEncoding(m, encoding); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", where, err))
+			}
+		}
+		if _, found := tags[tagNilEqualsEmptyTagName]; found && !holdsUnstructured(m.Type) {
+			errs = append(errs, fmt.Errorf("%s: +%s is only supported on maps and slices of interface{} values", where, tagNilEqualsEmptyTagName))
+		}
+		if values, found := tags[tagIgnoreNilFieldsTagName]; found && uft.Kind != types.Pointer {
+			switch {
+			case uft.Kind != types.Slice && uft.Kind != types.Map && uft.Kind != types.Interface:
+				errs = append(errs, fmt.Errorf("%s: +%s is only supported on pointer, slice, map and interface fields", where, tagIgnoreNilFieldsTagName))
+			case len(values) == 1 && values[0] == ignoreNilLeft:
+				errs = append(errs, fmt.Errorf("%s: +%s=%s is only supported on pointer fields, use %s", where, tagIgnoreNilFieldsTagName, ignoreNilLeft, ignoreNilEither))
+			}
+		}
+	}
+	return errs
+}
+
+// lintLines checks every comment line which looks like a tag of this
+// generator, written at place and described by where in errors.
+func lintLines(where string, place tagPlace, lines []string) []error {
+	var errs []error
+	for _, line := range lines {
+		if err := lintLine(place, strings.TrimSpace(line)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", where, err))
+		}
+	}
+	return errs
+}
+
+// lintLine checks a single comment line, returning nil if it is a valid tag
+// or does not look like a tag of this generator.
+func lintLine(place tagPlace, line string) error {
+	key, value := line, ""
+	if i := strings.Index(line, "="); i >= 0 {
+		key, value = line[:i], line[i+1:]
+	}
+	if strings.ContainsAny(key, " \t") {
+		// Prose mentioning the generator.
+		return nil
+	}
+
+	if !strings.HasPrefix(key, "+") {
+		if strings.HasPrefix(key, tagEnabledName) || strings.HasPrefix(key, strings.TrimPrefix(k8sTagPrefix, "+")+tagEnabledName) {
+			return fmt.Errorf("%q is not a tag, did you mean %q?", line, "+"+line)
+		}
+		return nil
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(key, k8sTagPrefix), "+")
+	if _, found := knownTags[name]; !found {
+		prefix := name
+		if i := strings.Index(name, ":"); i >= 0 {
+			prefix = name[:i]
+		}
+		if prefix != tagEnabledName && levenshtein(prefix, tagEnabledName) > 2 {
+			// A tag of another generator.
+			return nil
+		}
+		if suggestion := suggestTag(name); suggestion != "" {
+			return fmt.Errorf("unknown tag %q, did you mean %q?", line, "+"+suggestion)
+		}
+		return fmt.Errorf("unknown tag %q", line)
+	}
+
+	if knownTags[name]&place == 0 {
+		return fmt.Errorf("tag %q cannot be set on a %s", line, place)
+	}
+
+	switch name {
+	case tagEnabledName:
+		expected := []string{"true", "false"}
+		if place == placePackage {
+			expected = []string{tagValuePackage}
+		}
+		if v := strings.Split(value, ",")[0]; !containsString(expected, v) {
+			return fmt.Errorf("tag %q has unsupported value %q, expected %s", line, v, strings.Join(quoteAll(expected), " or "))
+		}
+	case tagIgnoreNilFieldsTagName:
+		if value != "true" && value != "false" && value != ignoreNilEither {
+			return fmt.Errorf("tag %q has unsupported value %q, expected \"true\", \"false\" or %q", line, value, ignoreNilEither)
+		}
+	case tagUnorderedArraysTagName, tagIgnoreUnexportedName, tagMatchesTagName, tagCycleSafeTagName, tagCompareTagName, tagNormalizeTagName, tagFieldMaskTagName, tagChangedFieldsTagName, tagMergePatchTagName, tagNilEqualsEmptyTagName, tagIsZeroTagName:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("tag %q has unsupported value %q, expected \"true\" or \"false\"", line, value)
+		}
+	case tagSemanticsTagName:
+		if value != semanticsGo && value != semanticsJSON {
+			return fmt.Errorf("tag %q has unsupported value %q, expected %q or %q", line, value, semanticsGo, semanticsJSON)
+		}
+	case tagProfileTagName, tagLockTagName:
+		if value == "" {
+			return fmt.Errorf("tag %q requires a value", line)
+		}
+	}
+	return nil
+}
+
+// suggestTag returns the known tag closest to name, or an empty string if
+// none is close enough to be a likely typo.
+func suggestTag(name string) string {
+	best, bestDistance := "", 4
+	for known := range knownTags {
+		if d := levenshtein(name, known); d < bestDistance || (d == bestDistance && known < best) {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteAll(list []string) []string {
+	quoted := make([]string, len(list))
+	for i := range list {
+		quoted[i] = fmt.Sprintf("%q", list[i])
+	}
+	return quoted
+}
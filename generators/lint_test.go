@@ -0,0 +1,107 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_lintLine(t *testing.T) {
+	testCases := []struct {
+		place  tagPlace
+		line   string
+		expect string
+	}{
+		{placeType, "Human comment about deepequal-gen", ""},
+		{placeType, "+deepequal-gen=true", ""},
+		{placeType, "+k8s:deepequal-gen=true", ""},
+		{placePackage, "+deepequal-gen=package,register", ""},
+		{placeMember, "+deepequal-gen:unordered-array=true", ""},
+		{placeType, "+deepequal-gen:lock=mu", ""},
+		{placeType, "+genclient", ""},
+		{placeType, "+k8s:deepcopy-gen=true", ""},
+		{
+			placeType, "deepequal-gen:unordered-array=true",
+			`"deepequal-gen:unordered-array=true" is not a tag, did you mean "+deepequal-gen:unordered-array=true"?`,
+		},
+		{
+			placeMember, "+deepequal-gen:unordered-arrays=true",
+			`unknown tag "+deepequal-gen:unordered-arrays=true", did you mean "+deepequal-gen:unordered-array"?`,
+		},
+		{
+			placeType, "+deepequalgen=true",
+			`unknown tag "+deepequalgen=true", did you mean "+deepequal-gen"?`,
+		},
+		{
+			placeType, "+deepequal-gen:compare-everything=true",
+			`unknown tag "+deepequal-gen:compare-everything=true"`,
+		},
+		{
+			placeMember, "+deepequal-gen:profile=status,ignore=Spec",
+			`tag "+deepequal-gen:profile=status,ignore=Spec" cannot be set on a field`,
+		},
+		{
+			placeType, "+deepequal-gen=package",
+			`tag "+deepequal-gen=package" has unsupported value "package", expected "true" or "false"`,
+		},
+		{
+			placeType, "+deepequal-gen:ignore-nil-fields=yes",
+			`tag "+deepequal-gen:ignore-nil-fields=yes" has unsupported value "yes", expected "true", "false" or "either"`,
+		},
+		{
+			placeType, "+deepequal-gen:lock",
+			`tag "+deepequal-gen:lock" requires a value`,
+		},
+		{placePackage, "+deepequal-gen:semantics=json", ""},
+		{
+			placeType, "+deepequal-gen:semantics=true",
+			`tag "+deepequal-gen:semantics=true" has unsupported value "true", expected "go" or "json"`,
+		},
+		{placeMember, "+deepequal-gen:nil-equals-empty=true", ""},
+		{
+			placeType, "+deepequal-gen:nil-equals-empty=always",
+			`tag "+deepequal-gen:nil-equals-empty=always" has unsupported value "always", expected "true" or "false"`,
+		},
+		{placeType, "+deepequal-gen:is-zero=true", ""},
+		{
+			placeMember, "+deepequal-gen:is-zero=true",
+			`tag "+deepequal-gen:is-zero=true" cannot be set on a field`,
+		},
+	}
+
+	for i, tc := range testCases {
+		err := lintLine(tc.place, tc.line)
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tc.expect {
+			t.Errorf("case[%d]: expected %q, got %q", i, tc.expect, got)
+		}
+	}
+}
+
+func Test_canonicalTags(t *testing.T) {
+	lines := []string{
+		"Human comment",
+		"+k8s:deepequal-gen=package",
+		" +k8s:deepequal-gen:unordered-array=true",
+		"+k8s:deepcopy-gen=package",
+	}
+	expect := []string{
+		"Human comment",
+		"+deepequal-gen=package",
+		"+deepequal-gen:unordered-array=true",
+		"+k8s:deepcopy-gen=package",
+	}
+	if got := canonicalTags(lines); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+	if lines[1] != "+k8s:deepequal-gen=package" {
+		t.Errorf("canonicalTags modified its argument")
+	}
+}
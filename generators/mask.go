@@ -0,0 +1,182 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// runtimePackage is the package providing the runtime support of the
+// generated methods.
+const runtimePackage = "github.com/wind-river/deepequal-gen/deepequal"
+
+// maskedMethodName returns the name of the variant of the DeepEqual method of
+// type t which only compares the fields selected by a field mask.
+func maskedMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepEqualMasked"
+	}
+	return "DeepEqualMasked"
+}
+
+// maskingTypes returns the types which get a DeepEqualMasked method
+// generated: the generated types which opted in with the field-mask tag, and
+// the types they nest which are generated by this run, so that masks apply to
+// nested fields.  An error is returned for every such type which cannot have
+// the method.
+func maskingTypes(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	masking := &optIn{
+		tag:         tagFieldMaskTagName,
+		eligible:    fieldwiseType,
+		requirement: "a struct, slice or map type",
+		nested:      nestedValueTypes,
+	}
+	result, errs := masking.types(generated, policy)
+
+	// The masked comparison does not carry the visited set.
+	for _, name := range result.List() {
+		if policy.cycleSafe.Has(name) {
+			errs = append(errs, fmt.Errorf("type %s: %s cannot be combined with %s", name, tagFieldMaskTagName, tagCycleSafeTagName))
+		}
+	}
+	return result, errs
+}
+
+// maskedMethod returns the name of the DeepEqualMasked method of type t, or
+// an empty string if nested values of type t are compared entirely.
+func (g *genDeepEqual) maskedMethod(t *types.Type) string {
+	name := maskedMethodName(t)
+	if g.policy.masking.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	if g.policy.hasGeneratedMethod(t, name) {
+		return name
+	}
+	return ""
+}
+
+// maskedElement returns the name of the DeepEqualMasked method of the
+// elements of slice or map type t, or of the values they point to, or an
+// empty string if they are compared entirely.
+func (g *genDeepEqual) maskedElement(t *types.Type) string {
+	uet := underlyingType(underlyingType(t).Elem)
+	if uet.Kind == types.Pointer {
+		return g.maskedMethod(uet.Elem)
+	}
+	return g.maskedMethod(underlyingType(t).Elem)
+}
+
+// jsonFieldName returns the name of member m in the JSON encoding of its
+// struct, an empty string if encoding/json leaves it out, and whether the
+// fields of the member are promoted to the struct instead.
+func jsonFieldName(m *types.Member) (string, bool) {
+	if namer.IsPrivateGoName(m.Name) && !m.Embedded {
+		return "", false
+	}
+	tag := reflect.StructTag(m.Tags).Get("json")
+	name := strings.Split(tag, ",")[0]
+	if name == "-" && tag == "-" {
+		return "", false
+	}
+	if name != "" {
+		return name, false
+	}
+	if m.Embedded {
+		et := underlyingType(m.Type)
+		if et.Kind == types.Pointer {
+			et = underlyingType(et.Elem)
+		}
+		if et.Kind == types.Struct {
+			return "", true
+		}
+		if namer.IsPrivateGoName(m.Name) {
+			return "", false
+		}
+	}
+	return m.Name, false
+}
+
+// fieldPathConstant returns the name of the generated constant holding the
+// JSON name of member m of type t.
+func fieldPathConstant(t *types.Type, m *types.Member) string {
+	return t.Name.Name + "Path" + strings.ToUpper(m.Name[:1]) + m.Name[1:]
+}
+
+// doMasked generates the DeepEqualMasked method of type t, and constants
+// holding the JSON names of the fields of struct types.
+func (g *genDeepEqual) doMasked(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["name"] = maskedMethodName(t)
+	args["method"] = deepEqualMethodName(t)
+	args["mask"] = types.Ref(runtimePackage, "FieldMask")
+
+	if ut := underlyingType(t); ut.Kind == types.Struct {
+		var constants []generator.Args
+		for i := range ut.Members {
+			m := &ut.Members[i]
+			if ignoresMember(ut, m) || isLockMember(m) {
+				continue
+			}
+			if name, _ := jsonFieldName(m); name != "" {
+				constants = append(constants, generator.Args{
+					"constant": fieldPathConstant(t, m),
+					"value":    fmt.Sprintf("%q", name),
+				})
+			}
+		}
+		if len(constants) > 0 {
+			sw.Do("// Names of the fields of $.type|raw$ in the paths of a $.mask|raw$.\n", args)
+			sw.Do("const (\n", nil)
+			for _, constant := range constants {
+				sw.Do("$.constant$ = $.value$\n", constant)
+			}
+			sw.Do(")\n\n", nil)
+		}
+	}
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, comparing the\n", args)
+	sw.Do("// receiver with other like $.method$, but only on the fields selected by\n", args)
+	sw.Do("// mask. in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$(other *$.type|raw$, mask $.mask|raw$) bool {\n", args)
+	sw.Do("if mask.All() {\n", nil)
+	sw.Do("return in.$.method$(other)\n", args)
+	sw.Do("}\n", nil)
+	sw.Do("if !mask.Selected() {\n", nil)
+	sw.Do("return true\n", nil)
+	sw.Do("}\n\n", nil)
+	g.masked = true
+	g.generateFor(t, sw)
+	g.masked = false
+	sw.Do("\nreturn true\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+// doFieldMask generates the opening of the block comparing member m of struct
+// type t when the field mask selects it, with the mask of the member in scope.
+func (g *genDeepEqual) doFieldMask(m *types.Member, sw *generator.SnippetWriter) {
+	name, promoted := jsonFieldName(m)
+	args := generator.Args{
+		"name":     fmt.Sprintf("%q", m.Name),
+		"jsonName": fmt.Sprintf("%q", name),
+	}
+	if promoted {
+		sw.Do("if mask := mask.Embedded($.name$); mask.Selected() {\n", args)
+	} else {
+		sw.Do("if mask := mask.Field($.name$, $.jsonName$); mask.Selected() {\n", args)
+	}
+}
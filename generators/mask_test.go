@@ -0,0 +1,111 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_maskingTypes(t *testing.T) {
+	newStruct := func(name string, comments ...string) *types.Type {
+		return &types.Type{
+			Name:         types.Name{Package: "example.com/api", Name: name},
+			Kind:         types.Struct,
+			CommentLines: comments,
+			Members:      []types.Member{{Name: "Name", Type: types.String}},
+		}
+	}
+	port := newStruct("Port")
+	status := newStruct("Status")
+	selector := newStruct("Selector")
+	selectors := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Selectors"},
+		Kind: types.Map,
+		Key:  types.String,
+		Elem: &types.Type{Kind: types.Pointer, Elem: selector},
+	}
+	spec := newStruct("Spec")
+	spec.Members = []types.Member{
+		{Name: "Ports", Type: &types.Type{Kind: types.Map, Key: types.String, Elem: port}},
+		{Name: "Selectors", Type: selectors},
+	}
+	root := newStruct("Root", "+deepequal-gen:field-mask=true")
+	root.Members = []types.Member{
+		{Name: "Spec", Type: &types.Type{Kind: types.Pointer, Elem: spec}},
+		{Name: "Status", Type: status, Tags: `deepequal:"json"`},
+	}
+	node := newStruct("Node", "+deepequal-gen:cycle-safe=true", "+deepequal-gen:field-mask=true")
+
+	testCases := []struct {
+		name      string
+		generated []*types.Type
+		expect    []string
+		errs      []string
+	}{
+		{
+			name:      "paths through pointers and maps",
+			generated: []*types.Type{port, root, selector, selectors, spec, status},
+			expect:    []string{"example.com/api.Port", "example.com/api.Root", "example.com/api.Selector", "example.com/api.Selectors", "example.com/api.Spec"},
+		},
+		{
+			name:      "nested types not generated",
+			generated: []*types.Type{root, selectors},
+			expect:    []string{"example.com/api.Root"},
+		},
+		{
+			name:      "cycle-safe types",
+			generated: []*types.Type{node},
+			expect:    []string{"example.com/api.Node"},
+			errs:      []string{"type example.com/api.Node: deepequal-gen:field-mask cannot be combined with deepequal-gen:cycle-safe"},
+		},
+	}
+
+	for _, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+		for _, t := range tc.generated {
+			policy.generating.Insert(t.Name.String())
+		}
+		policy.cycleSafe.Insert(node.Name.String())
+		masking, errs := maskingTypes(tc.generated, policy)
+		if strings.Join(masking.List(), ",") != strings.Join(tc.expect, ",") {
+			t.Errorf("%s: expected masking types %v, got %v", tc.name, tc.expect, masking.List())
+		}
+		if got := strings.Join(errs2strings(errs), "\n"); got != strings.Join(tc.errs, "\n") {
+			t.Errorf("%s: expected errors %q, got %q", tc.name, tc.errs, got)
+		}
+	}
+}
+
+func Test_jsonFieldName(t *testing.T) {
+	meta := &types.Type{Name: types.Name{Package: "example.com/api", Name: "Meta"}, Kind: types.Struct}
+	cases := []struct {
+		member   types.Member
+		name     string
+		promoted bool
+	}{
+		{member: types.Member{Name: "Spec", Type: types.String}, name: "Spec"},
+		{member: types.Member{Name: "Spec", Type: types.String, Tags: `json:"spec,omitempty"`}, name: "spec"},
+		{member: types.Member{Name: "Spec", Type: types.String, Tags: `json:",omitempty"`}, name: "Spec"},
+		{member: types.Member{Name: "Spec", Type: types.String, Tags: `json:"-"`}, name: ""},
+		{member: types.Member{Name: "Spec", Type: types.String, Tags: `json:"-,"`}, name: "-"},
+		{member: types.Member{Name: "spec", Type: types.String, Tags: `json:"spec"`}, name: ""},
+		{member: types.Member{Name: "Meta", Type: meta, Embedded: true}, promoted: true},
+		{member: types.Member{Name: "Meta", Type: meta, Embedded: true, Tags: `json:",inline"`}, promoted: true},
+		{member: types.Member{Name: "Meta", Type: &types.Type{Kind: types.Pointer, Elem: meta}, Embedded: true}, promoted: true},
+		{member: types.Member{Name: "Meta", Type: meta, Embedded: true, Tags: `json:"metadata"`}, name: "metadata"},
+		{member: types.Member{Name: "String", Type: types.String, Embedded: true}, name: "String"},
+	}
+	for i, c := range cases {
+		name, promoted := jsonFieldName(&c.member)
+		if name != c.name || promoted != c.promoted {
+			t.Errorf("case[%d]: expected %q and %t, got %q and %t", i, c.name, c.promoted, name, promoted)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+func extractMatchesTypeTag(t *types.Type) *enabledTagValue {
+	return extractTypeOrPackageTag(t, extractMatchesTag)
+}
+
+func extractMatchesTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagMatchesTagName, comments)
+}
+
+// matchesMethodName returns the name of the DeepMatches method of type t.
+// Like deepEqual, the method of a private type is unexported.
+func matchesMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepMatches"
+	}
+	return "DeepMatches"
+}
+
+// fieldwiseType returns whether methods matching or ordering values of type t
+// field by field can be generated: named structs, slices and maps whose
+// comparison is generated rather than written by the author.
+func fieldwiseType(t *types.Type) bool {
+	if t.Name.Package == "" || deepEqualMethodOrDie(t) != nil {
+		return false
+	}
+	switch underlyingType(t).Kind {
+	case types.Struct, types.Slice, types.Map:
+		return true
+	}
+	return false
+}
+
+// matchingTypes returns the types which get a DeepMatches method generated:
+// the generated types which opted in with the matches tag, and the types they
+// nest which are generated by this run, so that nested values are matched
+// with the same rule.
+func matchingTypes(generated []*types.Type, policy *comparisonPolicy) sets.String {
+	result := sets.NewString()
+
+	var walk func(t *types.Type)
+	walk = func(t *types.Type) {
+		for _, nt := range nestedValueTypes(t) {
+			if result.Has(nt.Name.String()) || !policy.generating.Has(nt.Name.String()) || !fieldwiseType(nt) {
+				continue
+			}
+			result.Insert(nt.Name.String())
+			walk(nt)
+		}
+	}
+
+	for _, t := range generated {
+		tag := extractMatchesTypeTag(t)
+		if tag == nil || tag.value != "true" || !fieldwiseType(t) || result.Has(t.Name.String()) {
+			continue
+		}
+		result.Insert(t.Name.String())
+		walk(t)
+	}
+	return result
+}
+
+// nestedValueTypes returns the types of the values nested in type t which are
+// matched or ordered field by field by the methods generated for t.
+func nestedValueTypes(t *types.Type) []*types.Type {
+	ut := underlyingType(t)
+	var nested []*types.Type
+	add := func(nt *types.Type) {
+		if unt := underlyingType(nt); unt.Kind == types.Pointer {
+			nt = unt.Elem
+		}
+		nested = append(nested, nt)
+	}
+	switch ut.Kind {
+	case types.Slice, types.Map:
+		add(ut.Elem)
+	case types.Struct:
+		for i := range ut.Members {
+			m := &ut.Members[i]
+			if ignoresMember(ut, m) || isLockMember(m) || fieldEncoding(m) != "" {
+				continue
+			}
+			ft := m.Type
+			if uft := underlyingType(ft); ft.Name.Package == "" && (uft.Kind == types.Slice || uft.Kind == types.Map) {
+				// Unnamed slices and maps are matched inline.
+				add(uft.Elem)
+			} else {
+				add(ft)
+			}
+		}
+	}
+	return nested
+}
+
+// matchMethod returns the name of the DeepMatches method of type t, or an
+// empty string if nested values of type t are compared for equality instead.
+func (g *genDeepEqual) matchMethod(t *types.Type) string {
+	name := matchesMethodName(t)
+	if g.policy.matching.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	return ""
+}
+
+// doMatches generates the DeepMatches method of type t.
+func (g *genDeepEqual) doMatches(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["name"] = matchesMethodName(t)
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, reporting whether actual\n", args)
+	sw.Do("// matches the receiver.  Zero values in the receiver match any value, slices\n", nil)
+	sw.Do("// match as prefixes, or as subsets if they are unordered, and maps as\n", nil)
+	sw.Do("// subsets.  in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$(actual *$.type|raw$) bool {\n", args)
+	sw.Do("if actual == nil {\n", nil)
+	sw.Do("return false\n", nil)
+	sw.Do("}\n\n", nil)
+
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Struct:
+		for i := range ut.Members {
+			m := &ut.Members[i]
+			if ignoresMember(ut, m) || isLockMember(m) {
+				continue
+			}
+			if fieldEncoding(m) != "" {
+				g.doEncodedNonZero(m, "in."+m.Name, sw)
+				g.doEncodedDiffers(m, "in."+m.Name, "actual."+m.Name, sw)
+				sw.Do("return false\n", nil)
+				sw.Do("}\n", nil)
+				sw.Do("}\n", nil)
+				continue
+			}
+			unordered := extractUnorderedArrayMemberTag(ut, m)
+			if unordered == nil && m.Type.Name.Package == "" {
+				// Unnamed slices follow the default of the package declaring
+				// the struct.
+				unordered = extractUnorderedArrayTag(typePackageComments(ut))
+			}
+			g.doMatchValue(m.Type, "in."+m.Name, "actual."+m.Name, unordered, g.path+"."+m.Name, sw)
+		}
+	case types.Slice:
+		g.doMatchSlice(t, "(*in)", "(*actual)", extractUnorderedArrayTypeTag(t), g.path, sw)
+	case types.Map:
+		g.doMatchMap(t, "(*in)", "(*actual)", g.path, sw)
+	}
+
+	sw.Do("\nreturn true\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+// doMatchValue generates code returning false unless the nested value actual
+// of type t matches the nested value in, which acts as a wildcard when it is
+// zero.  The unordered tag applies to unnamed slices.
+func (g *genDeepEqual) doMatchValue(t *types.Type, in, actual string, unordered *enabledTagValue, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":     in,
+		"actual": actual,
+		"method": g.matchMethod(t),
+		"type":   t,
+	}
+
+	switch {
+	case args["method"] != "":
+		sw.Do("if !$.in$.$.method$(&$.actual$) {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Pointer:
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("if $.actual$ == nil {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		if method := g.matchMethod(ut.Elem); method != "" {
+			args["method"] = method
+			sw.Do("if !$.in$.$.method$($.actual$) {\n", args)
+		} else if underlyingType(ut.Elem).IsPrimitive() {
+			sw.Do("if *$.in$ != *$.actual$ {\n", args)
+		} else {
+			g.doCompare(ut.Elem, in, actual, true, false, path, sw)
+		}
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+
+	case (ut.Kind == types.Slice || ut.Kind == types.Map) && deepEqualMethodOrDie(t) == nil:
+		sw.Do("if len($.in$) != 0 {\n", args)
+		if ut.Kind == types.Slice {
+			if unordered == nil {
+				unordered = extractUnorderedArrayTypeTag(t)
+			}
+			g.doMatchSlice(t, in, actual, unordered, path, sw)
+		} else {
+			g.doMatchMap(t, in, actual, path, sw)
+		}
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Slice || ut.Kind == types.Map:
+		sw.Do("if len($.in$) != 0 {\n", args)
+		g.doCompare(t, in, actual, false, false, path, sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Interface:
+		sw.Do("if $.in$ != nil {\n", args)
+		g.doCompare(t, in, actual, false, false, path, sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Builtin && ut.Name.Name == "bool":
+		sw.Do("if $.in$ && !$.actual$ {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Builtin:
+		args["zero"] = zeroLiteral(ut)
+		sw.Do("if $.in$ != $.zero$ && $.in$ != $.actual$ {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+
+	case isAtomicType(t):
+		switch t.Name.Name {
+		case "Bool":
+			sw.Do("if $.in$.Load() {\n", args)
+		case "Value":
+			sw.Do("if $.in$.Load() != nil {\n", args)
+		default:
+			sw.Do("if $.in$.Load() != 0 {\n", args)
+		}
+		g.doCompare(t, in, actual, false, false, path, sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Struct && IsComparable(ut) && t.Name.Package != "" && deepEqualMethodOrDie(t) == nil:
+		sw.Do("if $.in$ != ($.type|raw${}) && $.in$ != $.actual$ {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+
+	default:
+		args["valueOf"] = types.Ref("reflect", "ValueOf")
+		sw.Do("if !$.valueOf|raw$($.in$).IsZero() {\n", args)
+		g.doCompare(t, in, actual, false, false, path, sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	}
+}
+
+// doMatchSlice generates code returning false unless the slice actual of type
+// t starts with the elements of the slice in, or contains them in any order
+// if the slice is unordered.
+func (g *genDeepEqual) doMatchSlice(t *types.Type, in, actual string, unordered *enabledTagValue, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":     in,
+		"actual": actual,
+	}
+	if unordered != nil && unordered.value == "true" {
+		// Each element of in is matched by a distinct element of actual.
+		// Since zero values match any element, an element claimed by one
+		// pattern is handed over along an augmenting path when another
+		// pattern needs it.
+		sw.Do("if len($.in$) > len($.actual$) {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("owners := make([]int, len($.actual$))\n", args)
+		sw.Do("var assign func(i int, seen []bool) bool\n", nil)
+		sw.Do("assign = func(i int, seen []bool) bool {\n", nil)
+		sw.Do("for j := range $.actual$ {\n", args)
+		sw.Do("if seen[j] {\n", nil)
+		sw.Do("continue\n", nil)
+		sw.Do("}\n", nil)
+		g.doMatchElement(ut.Elem, in+"[i]", actual+"[j]", true, path+"[*]", sw)
+		sw.Do("seen[j] = true\n", nil)
+		sw.Do("if owners[j] == 0 || assign(owners[j]-1, seen) {\n", nil)
+		sw.Do("owners[j] = i + 1\n", nil)
+		sw.Do("return true\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("for i := range $.in$ {\n", args)
+		sw.Do("if !assign(i, make([]bool, len($.actual$))) {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	} else {
+		sw.Do("if len($.in$) > len($.actual$) {\n", args)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("for i := range $.in$ {\n", args)
+		g.doMatchElement(ut.Elem, in+"[i]", actual+"[i]", false, path+"[*]", sw)
+		sw.Do("return false\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	}
+}
+
+// doMatchMap generates code returning false unless every key of the map in is
+// present in the map actual of type t, with a matching value.
+func (g *genDeepEqual) doMatchMap(t *types.Type, in, actual string, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":     in,
+		"actual": actual,
+	}
+	sw.Do("for key, inValue := range $.in$ {\n", args)
+	sw.Do("actualValue, present := $.actual$[key]\n", args)
+	sw.Do("if !present {\n", nil)
+	sw.Do("return false\n", nil)
+	sw.Do("}\n", nil)
+	g.doMatchElement(ut.Elem, "inValue", "actualValue", false, path+"[*]", sw)
+	sw.Do("return false\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+}
+
+// doMatchElement generates the condition of an if statement which holds when
+// the element actual of type t matches the element in if equal is set, and
+// when it does not otherwise.  Elements are matched with DeepMatches if their
+// type has it, where nil pointers match any value, and are compared for
+// equality otherwise.
+func (g *genDeepEqual) doMatchElement(t *types.Type, in, actual string, equal bool, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":     in,
+		"actual": actual,
+		"method": g.matchMethod(t),
+		"not":    "!",
+		"op":     "!=",
+	}
+	if equal {
+		args["not"] = ""
+		args["op"] = "=="
+	}
+	switch {
+	case args["method"] != "":
+		sw.Do("if $.not$$.in$.$.method$(&$.actual$) {\n", args)
+	case ut.Kind == types.Pointer && g.matchMethod(ut.Elem) != "":
+		args["method"] = g.matchMethod(ut.Elem)
+		sw.Do("if $.not$($.in$ == nil || ($.actual$ != nil && $.in$.$.method$($.actual$))) {\n", args)
+	case ut.IsPrimitive():
+		sw.Do("if $.in$ $.op$ $.actual$ {\n", args)
+	case ut.Kind == types.Pointer && underlyingType(ut.Elem).IsPrimitive():
+		sw.Do("if $.not$(($.in$ == nil && $.actual$ == nil) || ($.in$ != nil && $.actual$ != nil && *$.in$ == *$.actual$)) {\n", args)
+	case ut.Kind == types.Pointer:
+		g.doCompare(ut.Elem, in, actual, true, equal, path, sw)
+	default:
+		g.doCompare(t, in, actual, false, equal, path, sw)
+	}
+}
+
+// zeroLiteral returns the literal of the zero value of non-boolean builtin
+// type t.
+func zeroLiteral(t *types.Type) string {
+	if t.Name.Name == "string" {
+		return `""`
+	}
+	return "0"
+}
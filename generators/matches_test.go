@@ -0,0 +1,64 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_matchingTypes(t *testing.T) {
+	str := types.String
+	port := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Port"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	nested := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Nested"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Ports", Type: &types.Type{Kind: types.Slice, Elem: port}},
+		},
+	}
+	other := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Other"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	external := &types.Type{
+		Name: types.Name{Package: "example.com/external", Name: "External"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	root := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Root"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:matches=true"},
+		Members: []types.Member{
+			{Name: "Nested", Type: &types.Type{Kind: types.Pointer, Elem: nested}},
+			{Name: "External", Type: external},
+		},
+	}
+
+	policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+	for _, t := range []*types.Type{port, nested, other, root} {
+		policy.generating.Insert(t.Name.String())
+	}
+	matching := matchingTypes([]*types.Type{nested, other, port, root}, policy)
+	if want := []string{"example.com/api.Nested", "example.com/api.Port", "example.com/api.Root"}; strings.Join(matching.List(), ",") != strings.Join(want, ",") {
+		t.Errorf("expected matching types %v, got %v", want, matching.List())
+	}
+}
@@ -0,0 +1,274 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// normalizeMethodName returns the name of the DeepNormalize method of type t.
+func normalizeMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepNormalize"
+	}
+	return "DeepNormalize"
+}
+
+// normalizingTypes returns the types which get a DeepNormalize method
+// generated: the generated types which opted in with the normalize tag, and
+// the types they nest which are generated by this run, so that nested values
+// are normalized as well.  An error is returned for every opted in type which
+// cannot have the method, and for every unordered slice of these types whose
+// elements cannot be sorted.
+func normalizingTypes(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	normalizing := &optIn{
+		tag:         tagNormalizeTagName,
+		eligible:    fieldwiseType,
+		requirement: "a struct, slice or map type",
+		nested:      nestedValueTypes,
+	}
+	result, errs := normalizing.types(generated, policy)
+
+	// Report the unordered slices which cannot be sorted before generating
+	// anything.
+	for _, t := range generated {
+		if !result.Has(t.Name.String()) {
+			continue
+		}
+		ut := underlyingType(t)
+		switch ut.Kind {
+		case types.Struct:
+			for i := range ut.Members {
+				m := &ut.Members[i]
+				if ignoresMember(ut, m) || isLockMember(m) || m.Type.Name.Package != "" || underlyingType(m.Type).Kind != types.Slice {
+					continue
+				}
+				if unorderedMember(ut, m) {
+					errs = append(errs, sortErrors(underlyingType(m.Type).Elem, t.Name.String()+"."+m.Name+"[*]", policy)...)
+				}
+			}
+		case types.Slice:
+			if tag := extractUnorderedArrayTypeTag(t); tag != nil && tag.value == "true" {
+				errs = append(errs, sortErrors(ut.Elem, t.Name.String()+"[*]", policy)...)
+			}
+		}
+	}
+	return result, errs
+}
+
+// unorderedMember returns whether the unnamed slice held by member m of
+// struct t is unordered.  Unnamed slices follow the default of the package
+// declaring the struct.
+func unorderedMember(t *types.Type, m *types.Member) bool {
+	unordered := extractUnorderedArrayMemberTag(t, m)
+	if unordered == nil {
+		unordered = extractUnorderedArrayTag(typePackageComments(t))
+	}
+	return unordered != nil && unordered.value == "true"
+}
+
+// sortErrors returns an error if the elements at path of an unordered slice,
+// of type t, cannot be sorted.  It follows the decisions made by sortLess.
+func sortErrors(t *types.Type, path string, policy *comparisonPolicy) []error {
+	ut := underlyingType(t)
+	switch {
+	case policy.orderMethod(t, policy.ordering) != "":
+		return nil
+	case ut.Kind == types.Builtin && ut.Name.Name == "bool", orderedBuiltin(ut):
+		return nil
+	case ut.Kind == types.Pointer && (policy.orderMethod(ut.Elem, policy.ordering) != "" || orderedBuiltin(underlyingType(ut.Elem))):
+		return nil
+	}
+	return []error{unsortedError(t, path)}
+}
+
+// unsortedError returns the error reported for the elements at path of an
+// unordered slice, of type t, which cannot be sorted.
+func unsortedError(t *types.Type, path string) error {
+	if ut := underlyingType(t); ut.Kind == types.Pointer {
+		t = ut.Elem
+	}
+	if t.Name.Package == "" {
+		return noOrderingError(t, path)
+	}
+	return fmt.Errorf("%s: unordered slices of %v cannot be sorted, add +%s=true to the element type", path, t, tagCompareTagName)
+}
+
+// normalizeMethod returns the name of the DeepNormalize method of type t, or
+// an empty string if values of type t are left untouched.
+func (g *genDeepEqual) normalizeMethod(t *types.Type) string {
+	name := normalizeMethodName(t)
+	if g.policy.normalizing.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	if g.policy.hasGeneratedMethod(t, name) {
+		return name
+	}
+	return ""
+}
+
+// doNormalize generates the DeepNormalize method of type t.
+func (g *genDeepEqual) doNormalize(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["name"] = normalizeMethodName(t)
+	args["method"] = deepEqualMethodName(t)
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, rewriting the receiver\n", args)
+	sw.Do("// into a canonical form among the values $.method$ considers equal to it:\n", args)
+	sw.Do("// unordered slices are sorted, and nil slices and maps compared as empty\n", nil)
+	sw.Do("// become empty. in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$() {\n", args)
+
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Struct:
+		ignoreNilFieldsTag := extractIgnoreNilFieldsTypeTag(ut)
+		for i := range ut.Members {
+			m := &ut.Members[i]
+			if ignoresMember(ut, m) || isLockMember(m) {
+				continue
+			}
+			ft := m.Type
+			uft := underlyingType(ft)
+			fieldArgs := generator.Args{
+				"name": m.Name,
+				"type": ft,
+			}
+			generated := ft.Name.Package == "" || g.normalizeMethod(ft) != ""
+			if (uft.Kind == types.Slice || uft.Kind == types.Map) && generated && ignoreNilMode(ignoreNilFieldsTag, ut, m) != ignoreNilEither && !distinguishesNil(ut, m) {
+				// Generated comparisons consider nil equal to empty, unless
+				// nil is encoded as null under JSON semantics.
+				sw.Do("if in.$.name$ == nil {\n", fieldArgs)
+				sw.Do("in.$.name$ = $.type|raw${}\n", fieldArgs)
+				sw.Do("}\n", nil)
+			}
+			unordered := extractUnorderedArrayMemberTag(ut, m)
+			if unordered == nil && ft.Name.Package == "" {
+				// Unnamed slices follow the default of the package declaring
+				// the struct.
+				unordered = extractUnorderedArrayTag(typePackageComments(ut))
+			}
+			g.doNormalizeValue(ft, "in."+m.Name, unordered, g.path+"."+m.Name, sw)
+		}
+	case types.Slice:
+		g.doNormalizeSlice(t, "(*in)", extractUnorderedArrayTypeTag(t), g.path, sw)
+	case types.Map:
+		g.doNormalizeMap(t, "(*in)", sw)
+	}
+
+	sw.Do("}\n\n", nil)
+}
+
+// doNormalizeValue generates code normalizing the nested value in of type t.
+// The unordered tag applies to unnamed slices.
+func (g *genDeepEqual) doNormalizeValue(t *types.Type, in string, unordered *enabledTagValue, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":     in,
+		"method": g.normalizeMethod(t),
+	}
+	switch {
+	case args["method"] != "":
+		sw.Do("$.in$.$.method$()\n", args)
+	case ut.Kind == types.Pointer && g.normalizeMethod(ut.Elem) != "":
+		args["method"] = g.normalizeMethod(ut.Elem)
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.in$.$.method$()\n", args)
+		sw.Do("}\n", nil)
+	case t.Name.Package == "" && ut.Kind == types.Slice:
+		g.doNormalizeSlice(t, in, unordered, path, sw)
+	case t.Name.Package == "" && ut.Kind == types.Map:
+		g.doNormalizeMap(t, in, sw)
+	}
+}
+
+// doNormalizeSlice generates code normalizing the elements of the slice in of
+// type t, then sorting them if the slice is unordered.
+func (g *genDeepEqual) doNormalizeSlice(t *types.Type, in string, unordered *enabledTagValue, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	uet := underlyingType(ut.Elem)
+	args := generator.Args{
+		"in":          in,
+		"method":      g.normalizeMethod(ut.Elem),
+		"sliceStable": types.Ref("sort", "SliceStable"),
+	}
+	switch {
+	case args["method"] != "":
+		sw.Do("for i := range $.in$ {\n", args)
+		sw.Do("$.in$[i].$.method$()\n", args)
+		sw.Do("}\n", nil)
+	case uet.Kind == types.Pointer && g.normalizeMethod(uet.Elem) != "":
+		args["method"] = g.normalizeMethod(uet.Elem)
+		sw.Do("for i := range $.in$ {\n", args)
+		sw.Do("if $.in$[i] != nil {\n", args)
+		sw.Do("$.in$[i].$.method$()\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	}
+
+	if unordered == nil || unordered.value != "true" {
+		return
+	}
+	args["less"] = g.sortLess(ut.Elem, in+"[i]", in+"[j]", path+"[*]")
+	sw.Do("$.sliceStable|raw$($.in$, func(i, j int) bool { return $.less$ })\n", args)
+}
+
+// sortLess returns the expression ordering the element a of an unordered
+// slice before the element b of type t, using DeepCompare or the natural order
+// of builtins.  Nil pointers sort first.
+func (g *genDeepEqual) sortLess(t *types.Type, a, b string, path string) string {
+	ut := underlyingType(t)
+	switch {
+	case g.orderMethod(t) != "":
+		return a + "." + g.orderMethod(t) + "(&" + b + ") < 0"
+	case ut.Kind == types.Builtin && ut.Name.Name == "bool":
+		return "!" + a + " && " + b
+	case orderedBuiltin(ut):
+		return a + " < " + b
+	case ut.Kind == types.Pointer && g.orderMethod(ut.Elem) != "":
+		return b + " != nil && (" + a + " == nil || " + a + "." + g.orderMethod(ut.Elem) + "(" + b + ") < 0)"
+	case ut.Kind == types.Pointer && orderedBuiltin(underlyingType(ut.Elem)):
+		return b + " != nil && (" + a + " == nil || *" + a + " < *" + b + ")"
+	}
+	klog.Fatalf("%v", unsortedError(t, path))
+	return ""
+}
+
+// doNormalizeMap generates code normalizing the values of the map in of type
+// t.
+func (g *genDeepEqual) doNormalizeMap(t *types.Type, in string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	uet := underlyingType(ut.Elem)
+	args := generator.Args{
+		"in":     in,
+		"method": g.normalizeMethod(ut.Elem),
+	}
+	switch {
+	case args["method"] != "":
+		// Map values are not addressable, normalize a copy.
+		sw.Do("for key, value := range $.in$ {\n", args)
+		sw.Do("value.$.method$()\n", args)
+		sw.Do("$.in$[key] = value\n", args)
+		sw.Do("}\n", nil)
+	case uet.Kind == types.Pointer && g.normalizeMethod(uet.Elem) != "":
+		args["method"] = g.normalizeMethod(uet.Elem)
+		sw.Do("for _, value := range $.in$ {\n", args)
+		sw.Do("if value != nil {\n", nil)
+		sw.Do("value.$.method$()\n", args)
+		sw.Do("}\n", nil)
+		sw.Do("}\n", nil)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_normalizingTypes(t *testing.T) {
+	str := types.String
+	port := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Port"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	root := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Root"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:normalize=true"},
+		Members: []types.Member{
+			{Name: "Ports", Type: &types.Type{Kind: types.Map, Key: str, Elem: &types.Type{Kind: types.Pointer, Elem: port}}},
+			{Name: "Names", Type: &types.Type{Kind: types.Slice, Elem: str}, CommentLines: []string{"+deepequal-gen:unordered-array=true"}},
+			{Name: "Listeners", Type: &types.Type{Kind: types.Slice, Elem: &types.Type{Kind: types.Pointer, Elem: port}}, CommentLines: []string{"+deepequal-gen:unordered-array=true"}},
+			{Name: "Ratios", Type: &types.Type{Kind: types.Slice, Elem: &types.Type{Name: types.Name{Name: "complex128"}, Kind: types.Builtin}}, CommentLines: []string{"+deepequal-gen:unordered-array=true"}},
+			{Name: "Others", Type: &types.Type{Kind: types.Slice, Elem: port}},
+		},
+	}
+	other := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Other"},
+		Kind: types.Struct,
+	}
+	name := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Name"},
+		Kind:         types.Alias,
+		Underlying:   str,
+		CommentLines: []string{"+deepequal-gen:normalize=true"},
+	}
+
+	sortable := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Sortable"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:normalize=true"},
+		Members: []types.Member{
+			{Name: "Ports", Type: &types.Type{Kind: types.Map, Key: str, Elem: &types.Type{Kind: types.Pointer, Elem: port}}},
+			{Name: "Names", Type: &types.Type{Kind: types.Slice, Elem: str}, CommentLines: []string{"+deepequal-gen:unordered-array=true"}},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		generated []*types.Type
+		expect    []string
+		errs      []string
+	}{
+		{
+			name:      "nested types generated by this run",
+			generated: []*types.Type{other, port, sortable},
+			expect:    []string{"example.com/api.Port", "example.com/api.Sortable"},
+		},
+		{
+			name:      "unordered slices which cannot be sorted",
+			generated: []*types.Type{other, port, root},
+			expect:    []string{"example.com/api.Port", "example.com/api.Root"},
+			errs: []string{
+				"example.com/api.Root.Listeners[*]: unordered slices of example.com/api.Port cannot be sorted, add +deepequal-gen:compare=true to the element type",
+				"example.com/api.Root.Ratios[*]: unnamed type complex128 cannot have a DeepCompare method, use a named type instead",
+			},
+		},
+		{
+			name:      "ineligible type",
+			generated: []*types.Type{name},
+			errs:      []string{"type example.com/api.Name: deepequal-gen:normalize requires a struct, slice or map type with a generated DeepEqual method"},
+		},
+	}
+
+	for _, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+		for _, t := range tc.generated {
+			policy.generating.Insert(t.Name.String())
+		}
+		normalizing, errs := normalizingTypes(tc.generated, policy)
+		if strings.Join(normalizing.List(), ",") != strings.Join(tc.expect, ",") {
+			t.Errorf("%s: expected normalizing types %v, got %v", tc.name, tc.expect, normalizing.List())
+		}
+		if got := strings.Join(errs2strings(errs), "\n"); got != strings.Join(tc.errs, "\n") {
+			t.Errorf("%s: expected errors %q, got %q", tc.name, tc.errs, got)
+		}
+	}
+}
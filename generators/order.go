@@ -0,0 +1,413 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// compareMethodName returns the name of the DeepCompare method of type t.
+func compareMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepCompare"
+	}
+	return "DeepCompare"
+}
+
+// orderingTypes returns the types which get a DeepCompare method generated:
+// the generated types which opted in with the compare tag, and the types they
+// nest which are generated by this run, so that nested values are ordered
+// with the same rule.  An error is returned for every opted in type which
+// cannot be ordered consistently with its DeepEqual method.
+func orderingTypes(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	ordering := &optIn{
+		tag:         tagCompareTagName,
+		eligible:    fieldwiseType,
+		requirement: "a struct, slice or map type",
+		nested:      nestedValueTypes,
+	}
+	result, errs := ordering.types(generated, policy)
+
+	// Nil fields which are ignored make DeepEqual inconsistent with any order.
+	for _, t := range generated {
+		ut := underlyingType(t)
+		if !result.Has(t.Name.String()) || ut.Kind != types.Struct {
+			continue
+		}
+		typeTag := extractIgnoreNilFieldsTypeTag(ut)
+		for i := range ut.Members {
+			if ignoreNilMode(typeTag, ut, &ut.Members[i]) != "" {
+				errs = append(errs, fmt.Errorf("type %v: %s cannot be ordered because field %s sets %s", t, tagCompareTagName, ut.Members[i].Name, tagIgnoreNilFieldsTagName))
+			}
+			// Values with equal encodings may still differ.
+			if encoding := fieldEncoding(&ut.Members[i]); encoding != "" {
+				errs = append(errs, fmt.Errorf("type %v: %s cannot be ordered because field %s is compared by its %s encoding", t, tagCompareTagName, ut.Members[i].Name, encoding))
+			}
+		}
+	}
+
+	// Report the nested values without an order before generating anything.
+	for _, t := range generated {
+		if !result.Has(t.Name.String()) {
+			continue
+		}
+		ut := underlyingType(t)
+		switch ut.Kind {
+		case types.Struct:
+			for _, m := range comparedMembers(t) {
+				if fieldEncoding(m) == "" {
+					errs = append(errs, orderErrors(m.Type, t.Name.String()+"."+m.Name, result, policy, sets.NewString())...)
+				}
+			}
+		case types.Slice:
+			errs = append(errs, orderErrors(ut.Elem, t.Name.String()+"[*]", result, policy, sets.NewString())...)
+		case types.Map:
+			errs = append(errs, orderKeyErrors(ut, t.Name.String(), result, policy, sets.NewString())...)
+		}
+	}
+	return result, errs
+}
+
+// orderErrors returns an error for every value nested in a value of type t,
+// at path, which the DeepCompare methods of the types in ordering cannot
+// order.  It follows the decisions made by doOrder, and seen holds the named
+// types already checked.
+func orderErrors(t *types.Type, path string, ordering sets.String, policy *comparisonPolicy, seen sets.String) []error {
+	ut := underlyingType(t)
+	if t.Name.Package != "" {
+		if seen.Has(t.Name.String()) {
+			return nil
+		}
+		seen.Insert(t.Name.String())
+	}
+	if policy.orderMethod(t, ordering) != "" {
+		return nil
+	}
+	switch {
+	case ut.Kind == types.Builtin && ut.Name.Name == "bool", orderedBuiltin(ut):
+		return nil
+	case ut.Kind == types.Pointer:
+		return orderErrors(ut.Elem, path, ordering, policy, seen)
+	case ut.Kind == types.Slice && deepEqualMethodOrDie(t) == nil:
+		return orderErrors(ut.Elem, path+"[*]", ordering, policy, seen)
+	case ut.Kind == types.Map && deepEqualMethodOrDie(t) == nil:
+		return orderKeyErrors(ut, path, ordering, policy, seen)
+	case isAtomicType(t) && t.Name.Name != "Value":
+		return nil
+	}
+	return []error{noOrderingError(t, path)}
+}
+
+// noOrderingError returns the error reported for the value at path of type t,
+// which has no ordering.
+func noOrderingError(t *types.Type, path string) error {
+	if t.Name.Package == "" {
+		return fmt.Errorf("%s: unnamed type %v cannot have a DeepCompare method, use a named type instead", path, t)
+	}
+	return fmt.Errorf("%s: type %v has no ordering, add +%s=true to it or define its %s method", path, t, tagCompareTagName, compareMethodName(t))
+}
+
+// orderKeyErrors returns the errors of orderErrors for the keys and values of
+// map type t.
+func orderKeyErrors(t *types.Type, path string, ordering sets.String, policy *comparisonPolicy, seen sets.String) []error {
+	var errs []error
+	if !orderedBuiltin(underlyingType(t.Key)) {
+		errs = append(errs, fmt.Errorf("%s: keys of type %v have no ordering", path, t.Key))
+	}
+	return append(errs, orderErrors(t.Elem, path+"[*]", ordering, policy, seen)...)
+}
+
+// orderMethod returns the name of the DeepCompare method of type t, or an
+// empty string if it has none.
+func (g *genDeepEqual) orderMethod(t *types.Type) string {
+	return g.policy.orderMethod(t, g.policy.ordering)
+}
+
+// orderMethod returns the name of the DeepCompare method of type t, given the
+// types in ordering which get one generated, or an empty string if it has
+// none.
+func (p *comparisonPolicy) orderMethod(t *types.Type, ordering sets.String) string {
+	name := compareMethodName(t)
+	if ordering.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	if p.hasGeneratedMethod(t, name) {
+		return name
+	}
+	return ""
+}
+
+// orderedBuiltin returns whether values of builtin type t are ordered by <.
+func orderedBuiltin(t *types.Type) bool {
+	if t.Kind != types.Builtin {
+		return false
+	}
+	switch t.Name.Name {
+	case "bool", "complex64", "complex128", "unsafe.Pointer":
+		return false
+	}
+	return true
+}
+
+// sortsUnordered returns whether unordered slices of elements of type t are
+// compared by sorting copies of them, which is possible when the elements
+// have a DeepCompare method, and required for builtin elements of a type with
+// a DeepCompare method so that both methods agree on duplicate elements.
+func (g *genDeepEqual) sortsUnordered(t *types.Type) bool {
+	if g.orderMethod(t) != "" {
+		return true
+	}
+	return g.ordered && orderedBuiltin(underlyingType(t))
+}
+
+// doSortedSlice generates the comparison of the unordered slices *in and
+// *other of the same length as the comparison of sorted copies of them.
+func (g *genDeepEqual) doSortedSlice(t *types.Type, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"elem":   ut.Elem,
+		"slice":  types.Ref("sort", "Slice"),
+		"method": g.orderMethod(ut.Elem),
+	}
+	sw.Do("inSorted := append([]$.elem|raw$(nil), (*in)...)\n", args)
+	sw.Do("otherSorted := append([]$.elem|raw$(nil), (*other)...)\n", args)
+	if args["method"] != "" {
+		sw.Do("$.slice|raw$(inSorted, func(i, j int) bool { return inSorted[i].$.method$(&inSorted[j]) < 0 })\n", args)
+		sw.Do("$.slice|raw$(otherSorted, func(i, j int) bool { return otherSorted[i].$.method$(&otherSorted[j]) < 0 })\n", args)
+		sw.Do("for i := range inSorted {\n", nil)
+		sw.Do("if inSorted[i].$.method$(&otherSorted[i]) != 0 {\n", args)
+	} else {
+		sw.Do("$.slice|raw$(inSorted, func(i, j int) bool { return inSorted[i] < inSorted[j] })\n", args)
+		sw.Do("$.slice|raw$(otherSorted, func(i, j int) bool { return otherSorted[i] < otherSorted[j] })\n", args)
+		sw.Do("for i := range inSorted {\n", nil)
+		sw.Do("if inSorted[i] != otherSorted[i] {\n", nil)
+	}
+	sw.Do("return false\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+}
+
+// doOrdering generates the DeepCompare method of type t.
+func (g *genDeepEqual) doOrdering(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["name"] = compareMethodName(t)
+	args["method"] = deepEqualMethodName(t)
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, ordering the receiver\n", args)
+	sw.Do("// and other field by field.  It returns 0 exactly when $.method$ reports them\n", args)
+	sw.Do("// equal, and a negative or positive number when the receiver sorts before or\n", nil)
+	sw.Do("// after other. in must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$(other *$.type|raw$) int {\n", args)
+	sw.Do("if other == nil {\n", nil)
+	sw.Do("return 1\n", nil)
+	sw.Do("}\n\n", nil)
+
+	ut := underlyingType(t)
+	switch ut.Kind {
+	case types.Struct:
+		for i := range ut.Members {
+			m := &ut.Members[i]
+			if ignoresMember(ut, m) || isLockMember(m) {
+				continue
+			}
+			unordered := extractUnorderedArrayMemberTag(ut, m)
+			if unordered == nil && m.Type.Name.Package == "" {
+				// Unnamed slices follow the default of the package declaring
+				// the struct.
+				unordered = extractUnorderedArrayTag(typePackageComments(ut))
+			}
+			if uft := underlyingType(m.Type); (uft.Kind == types.Slice || uft.Kind == types.Map) && distinguishesNil(ut, m) {
+				// DeepEqual tells nil from empty, so nil sorts first.
+				sw.Do("if (in.$.$ == nil) != (other.$.$ == nil) {\n", m.Name)
+				sw.Do("if in.$.$ == nil {\n", m.Name)
+				sw.Do("return -1\n", nil)
+				sw.Do("}\n", nil)
+				sw.Do("return 1\n", nil)
+				sw.Do("}\n", nil)
+			}
+			g.doOrder(m.Type, "in."+m.Name, "other."+m.Name, unordered, g.path+"."+m.Name, sw)
+		}
+	case types.Slice:
+		g.doOrderSlice(t, "(*in)", "(*other)", extractUnorderedArrayTypeTag(t), g.path, sw)
+	case types.Map:
+		g.doOrderMap(t, "(*in)", "(*other)", g.path, sw)
+	}
+
+	sw.Do("\nreturn 0\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+// doOrder generates code returning the order of the nested values in and
+// other of type t if they differ.  The unordered tag applies to unnamed
+// slices.
+func (g *genDeepEqual) doOrder(t *types.Type, in, other string, unordered *enabledTagValue, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":     in,
+		"other":  other,
+		"method": g.orderMethod(t),
+	}
+
+	switch {
+	case args["method"] != "":
+		sw.Do("if c := $.in$.$.method$(&$.other$); c != 0 {\n", args)
+		sw.Do("return c\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Builtin && ut.Name.Name == "bool":
+		sw.Do("if $.in$ != $.other$ {\n", args)
+		sw.Do("if !$.in$ {\n", args)
+		sw.Do("return -1\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("return 1\n", nil)
+		sw.Do("}\n", nil)
+
+	case orderedBuiltin(ut):
+		sw.Do("if $.in$ != $.other$ {\n", args)
+		sw.Do("if $.in$ < $.other$ {\n", args)
+		sw.Do("return -1\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("return 1\n", nil)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Pointer:
+		sw.Do("if ($.in$ == nil) != ($.other$ == nil) {\n", args)
+		sw.Do("if $.in$ == nil {\n", args)
+		sw.Do("return -1\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("return 1\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("if $.in$ != nil {\n", args)
+		g.doOrder(ut.Elem, "(*"+in+")", "(*"+other+")", nil, path, sw)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Slice && deepEqualMethodOrDie(t) == nil:
+		if unordered == nil {
+			unordered = extractUnorderedArrayTypeTag(t)
+		}
+		sw.Do("{\n", nil)
+		g.doOrderSlice(t, in, other, unordered, path, sw)
+		sw.Do("}\n", nil)
+
+	case ut.Kind == types.Map && deepEqualMethodOrDie(t) == nil:
+		sw.Do("{\n", nil)
+		g.doOrderMap(t, in, other, path, sw)
+		sw.Do("}\n", nil)
+
+	case isAtomicType(t) && t.Name.Name != "Value":
+		if t.Name.Name == "Bool" {
+			sw.Do("if $.in$.Load() != $.other$.Load() {\n", args)
+			sw.Do("if !$.in$.Load() {\n", args)
+		} else {
+			sw.Do("if $.in$.Load() != $.other$.Load() {\n", args)
+			sw.Do("if $.in$.Load() < $.other$.Load() {\n", args)
+		}
+		sw.Do("return -1\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("return 1\n", nil)
+		sw.Do("}\n", nil)
+
+	default:
+		klog.Fatalf("%v", noOrderingError(t, path))
+	}
+}
+
+// doOrderSlice generates code returning the order of the slices in and other
+// of type t if they differ: the order of their first differing elements, or
+// of their lengths.  Unordered slices are ordered like sorted copies of them.
+func (g *genDeepEqual) doOrderSlice(t *types.Type, in, other string, unordered *enabledTagValue, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	args := generator.Args{
+		"in":    in,
+		"other": other,
+		"elem":  ut.Elem,
+		"slice": types.Ref("sort", "Slice"),
+	}
+	g.doOrderFunc(ut.Elem, path+"[*]", sw)
+	if unordered != nil && unordered.value == "true" {
+		sw.Do("inSorted := append([]$.elem|raw$(nil), $.in$...)\n", args)
+		sw.Do("otherSorted := append([]$.elem|raw$(nil), $.other$...)\n", args)
+		sw.Do("$.slice|raw$(inSorted, func(i, j int) bool { return compare(&inSorted[i], &inSorted[j]) < 0 })\n", args)
+		sw.Do("$.slice|raw$(otherSorted, func(i, j int) bool { return compare(&otherSorted[i], &otherSorted[j]) < 0 })\n", args)
+		args["in"], args["other"] = "inSorted", "otherSorted"
+	}
+	sw.Do("for i := 0; i < len($.in$) && i < len($.other$); i++ {\n", args)
+	sw.Do("if c := compare(&$.in$[i], &$.other$[i]); c != 0 {\n", args)
+	sw.Do("return c\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("if len($.in$) != len($.other$) {\n", args)
+	sw.Do("if len($.in$) < len($.other$) {\n", args)
+	sw.Do("return -1\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("return 1\n", nil)
+	sw.Do("}\n", nil)
+}
+
+// doOrderMap generates code returning the order of the maps in and other of
+// type t if they differ: the order of their lengths, or of their values for
+// the first key, in key order, whose values differ.  A missing value sorts
+// before any other.
+func (g *genDeepEqual) doOrderMap(t *types.Type, in, other string, path string, sw *generator.SnippetWriter) {
+	ut := underlyingType(t)
+	if !orderedBuiltin(underlyingType(ut.Key)) {
+		klog.Fatalf("%s: keys of type %v have no ordering", path, ut.Key)
+	}
+	args := generator.Args{
+		"in":    in,
+		"other": other,
+		"key":   ut.Key,
+		"slice": types.Ref("sort", "Slice"),
+	}
+	g.doOrderFunc(ut.Elem, path+"[*]", sw)
+	sw.Do("if len($.in$) != len($.other$) {\n", args)
+	sw.Do("if len($.in$) < len($.other$) {\n", args)
+	sw.Do("return -1\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("return 1\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("keys := make([]$.key|raw$, 0, len($.in$))\n", args)
+	sw.Do("for key := range $.in$ {\n", args)
+	sw.Do("keys = append(keys, key)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("for key := range $.other$ {\n", args)
+	sw.Do("if _, found := $.in$[key]; !found {\n", args)
+	sw.Do("keys = append(keys, key)\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("$.slice|raw$(keys, func(i, j int) bool { return keys[i] < keys[j] })\n", args)
+	sw.Do("for _, key := range keys {\n", nil)
+	sw.Do("inValue, inFound := $.in$[key]\n", args)
+	sw.Do("otherValue, otherFound := $.other$[key]\n", args)
+	sw.Do("if inFound != otherFound {\n", nil)
+	sw.Do("if !inFound {\n", nil)
+	sw.Do("return -1\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("return 1\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("if c := compare(&inValue, &otherValue); c != 0 {\n", nil)
+	sw.Do("return c\n", nil)
+	sw.Do("}\n", nil)
+	sw.Do("}\n", nil)
+}
+
+// doOrderFunc generates a compare function ordering two values of type t.
+func (g *genDeepEqual) doOrderFunc(t *types.Type, path string, sw *generator.SnippetWriter) {
+	sw.Do("compare := func(in, other *$.|raw$) int {\n", t)
+	g.doOrder(t, "(*in)", "(*other)", nil, path, sw)
+	sw.Do("return 0\n", nil)
+	sw.Do("}\n", nil)
+}
@@ -0,0 +1,112 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_orderingTypes(t *testing.T) {
+	str := types.String
+	port := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Port"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	root := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Root"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:compare=true"},
+		Members: []types.Member{
+			{Name: "Ports", Type: &types.Type{Kind: types.Slice, Elem: port}},
+		},
+	}
+	optional := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Optional"},
+		Kind: types.Struct,
+		CommentLines: []string{
+			"+deepequal-gen:compare=true",
+			"+deepequal-gen:ignore-nil-fields=true",
+		},
+		Members: []types.Member{
+			{Name: "Port", Type: &types.Type{Kind: types.Pointer, Elem: port}},
+		},
+	}
+	encoded := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Encoded"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:compare=true"},
+		Members: []types.Member{
+			{Name: "Raw", Type: str, Tags: `deepequal:"json"`},
+		},
+	}
+	unorderable := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Unorderable"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:compare=true"},
+		Members: []types.Member{
+			{Name: "Ratio", Type: &types.Type{Kind: types.Pointer, Elem: &types.Type{Name: types.Name{Name: "complex128"}, Kind: types.Builtin}}},
+			{Name: "Flags", Type: &types.Type{Kind: types.Map, Key: types.Bool, Elem: str}},
+			{Name: "Origin", Type: &types.Type{
+				Name: types.Name{Package: "example.com/geometry", Name: "point"},
+				Kind: types.Struct,
+			}},
+		},
+	}
+	name := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Name"},
+		Kind:         types.Alias,
+		Underlying:   str,
+		CommentLines: []string{"+deepequal-gen:compare=true"},
+	}
+
+	// The package default applies to the types which can be ordered.
+	installUniverse(types.Universe{"example.com/api": &types.Package{Path: "example.com/api"}})
+	defer installUniverse(nil)
+	headerComments["example.com/api"] = []string{"+deepequal-gen:compare=true"}
+	labels := &types.Type{
+		Name:       types.Name{Package: "example.com/api", Name: "Labels"},
+		Kind:       types.Alias,
+		Underlying: str,
+	}
+
+	policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+	generated := []*types.Type{labels, name, optional, port, root, encoded, unorderable}
+	for _, t := range generated {
+		policy.generating.Insert(t.Name.String())
+	}
+	ordering, errs := orderingTypes(generated, policy)
+	if want := []string{"example.com/api.Encoded", "example.com/api.Optional", "example.com/api.Port", "example.com/api.Root", "example.com/api.Unorderable"}; strings.Join(ordering.List(), ",") != strings.Join(want, ",") {
+		t.Errorf("expected ordering types %v, got %v", want, ordering.List())
+	}
+	if len(errs) != 6 {
+		t.Fatalf("expected six errors, got %v", errs)
+	}
+	if msg := errs[0].Error(); !strings.Contains(msg, "example.com/api.Name") || !strings.Contains(msg, "requires a struct, slice or map type") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+	if msg := errs[1].Error(); !strings.Contains(msg, "example.com/api.Optional") || !strings.Contains(msg, "field Port sets "+tagIgnoreNilFieldsTagName) {
+		t.Errorf("unexpected error: %v", msg)
+	}
+	if msg := errs[2].Error(); !strings.Contains(msg, "example.com/api.Encoded") || !strings.Contains(msg, "field Raw is compared by its json encoding") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+	if msg := errs[3].Error(); !strings.Contains(msg, "example.com/api.Unorderable.Ratio: unnamed type complex128 cannot have a DeepCompare method, use a named type instead") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+	if msg := errs[4].Error(); !strings.Contains(msg, "example.com/api.Unorderable.Flags: keys of type bool have no ordering") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+	if msg := errs[5].Error(); !strings.Contains(msg, "example.com/api.Unorderable.Origin: type example.com/geometry.point has no ordering") || !strings.Contains(msg, "define its deepCompare method") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+}
@@ -0,0 +1,229 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog"
+)
+
+// mergePatchMethodName returns the name of the DeepMergePatch method of type
+// t.
+func mergePatchMethodName(t *types.Type) string {
+	if namer.IsPrivateGoName(t.Name.Name) {
+		return "deepMergePatch"
+	}
+	return "DeepMergePatch"
+}
+
+// patchedStruct returns the struct type of the values of type t which are
+// patched member by member: t itself or the type t points to.
+func patchedStruct(t *types.Type) *types.Type {
+	if ut := underlyingType(t); ut.Kind == types.Pointer {
+		t = ut.Elem
+	}
+	if underlyingType(t).Kind != types.Struct {
+		return nil
+	}
+	return t
+}
+
+// mergePatchTypes returns the types which get a DeepMergePatch method
+// generated: the generated structs which opted in with the merge-patch tag,
+// and the generated structs their fields hold or point to, so that nested
+// objects are patched member by member.  An error is returned for every opted
+// in type which cannot have the method.
+func mergePatchTypes(generated []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	patching := &optIn{
+		tag: tagMergePatchTagName,
+		eligible: func(t *types.Type) bool {
+			return fieldwiseType(t) && underlyingType(t).Kind == types.Struct
+		},
+		requirement: "a struct type",
+		nested:      patchedStructs,
+	}
+	return patching.types(generated, policy)
+}
+
+// patchedStructs returns the struct types held or pointed to by the fields of
+// struct type t which are patched member by member.  Fields compared by their
+// encoding are set as a whole.
+func patchedStructs(t *types.Type) []*types.Type {
+	var result []*types.Type
+	for _, m := range comparedMembers(t) {
+		if fieldEncoding(m) != "" {
+			continue
+		}
+		if nt := patchedStruct(m.Type); nt != nil {
+			result = append(result, nt)
+		}
+	}
+	return result
+}
+
+// mergePatchMethod returns the name of the DeepMergePatch method of type t,
+// or an empty string if it has none.
+func (g *genDeepEqual) mergePatchMethod(t *types.Type) string {
+	name := mergePatchMethodName(t)
+	if g.policy.mergePatching.Has(t.Name.String()) {
+		return name
+	}
+	if _, found := t.Methods[name]; found {
+		return name
+	}
+	if g.policy.hasGeneratedMethod(t, name) {
+		return name
+	}
+	return ""
+}
+
+// jsonFieldOptions returns whether the json struct tag of member m sets the
+// omitempty and string options.
+func jsonFieldOptions(m *types.Member) (omitempty, quoted bool) {
+	options := strings.Split(reflect.StructTag(m.Tags).Get("json"), ",")
+	for _, option := range options[1:] {
+		switch option {
+		case "omitempty":
+			omitempty = true
+		case "string":
+			quoted = true
+		}
+	}
+	return omitempty, quoted
+}
+
+// doMergePatch generates the DeepMergePatch method of struct type t.
+func (g *genDeepEqual) doMergePatch(t *types.Type, sw *generator.SnippetWriter) {
+	args := argsFromType(t)
+	args["name"] = mergePatchMethodName(t)
+	args["method"] = deepEqualMethodName(t)
+	args["patch"] = types.Ref(runtimePackage, "MergePatch")
+
+	klog.V(5).Infof("Generating %s function for type %v", args["name"], t)
+	sw.Do("// $.name$ is an autogenerated deepequal function, returning the RFC 7386\n", args)
+	sw.Do("// JSON merge patch turning the JSON encoding of the receiver into that of\n", nil)
+	sw.Do("// modified. Fields which $.method$ considers equal are left out of the patch,\n", args)
+	sw.Do("// and slices are replaced as a whole. in and modified must be non-nil.\n", nil)
+	sw.Do("func (in *$.type|raw$) $.name$(modified *$.type|raw$) ([]byte, error) {\n", args)
+	sw.Do("other := modified\n", nil)
+	sw.Do("patch := $.patch|raw${}\n\n", args)
+
+	g.ordered = g.policy.ordering.Has(t.Name.String())
+	ignoreNilFieldsTag := extractIgnoreNilFieldsTypeTag(underlyingType(t))
+	for _, m := range comparedMembers(t) {
+		if jsonOmitsMember(m) {
+			// The field is left out of JSON.
+			continue
+		}
+		g.doMemberDiffers(t, m, ignoreNilFieldsTag, sw)
+		g.doMemberPatch(m, sw)
+		sw.Do("}\n", nil)
+	}
+	g.ordered = false
+
+	sw.Do("\nreturn patch.Marshal()\n", nil)
+	sw.Do("}\n\n", nil)
+}
+
+// doMemberPatch generates the code adding the patch of member m, which
+// differs between in and other, to the merge patch.
+func (g *genDeepEqual) doMemberPatch(m *types.Member, sw *generator.SnippetWriter) {
+	ft := m.Type
+	uft := underlyingType(ft)
+	name, promoted := jsonFieldName(m)
+	omitempty, quoted := jsonFieldOptions(m)
+	args := generator.Args{
+		"name":   m.Name,
+		"json":   fmt.Sprintf("%q", name),
+		"method": g.mergePatchMethod(ft),
+	}
+	if fieldEncoding(m) != "" {
+		// Fields compared by their encoding are set as a whole.
+		args["method"] = ""
+	}
+
+	if promoted {
+		if args["method"] != "" {
+			sw.Do("if nested, err := in.$.name$.$.method$(&other.$.name$); err != nil {\n", args)
+			sw.Do("return nil, err\n", nil)
+			sw.Do("} else if err := patch.Inline(nested); err != nil {\n", nil)
+		} else {
+			sw.Do("if err := patch.DiffInline(in.$.name$, other.$.name$); err != nil {\n", args)
+		}
+		sw.Do("return nil, err\n", nil)
+		sw.Do("}\n", nil)
+		return
+	}
+
+	// Values which encoding/json leaves out become removals.
+	empty := ""
+	switch uft.Kind {
+	case types.Builtin:
+		empty = "other." + m.Name + " == " + zeroLiteral(uft)
+		if uft.Name.Name == "bool" {
+			empty = "!other." + m.Name
+		}
+	case types.Slice, types.Map:
+		empty = "len(other." + m.Name + ") == 0"
+	case types.Pointer, types.Interface:
+		empty = "other." + m.Name + " == nil"
+	}
+	if uft.Kind == types.Pointer && g.mergePatchMethod(uft.Elem) != "" && fieldEncoding(m) == "" {
+		// Nil pointers are encoded as null, which removes the member.
+		omitempty = true
+	}
+	if omitempty && empty != "" {
+		args["empty"] = empty
+		sw.Do("if $.empty$ {\n", args)
+		sw.Do("patch.Remove($.json$)\n", args)
+		sw.Do("} else ", nil)
+	}
+
+	switch {
+	case args["method"] != "":
+		sw.Do("if nested, err := in.$.name$.$.method$(&other.$.name$); err != nil {\n", args)
+		sw.Do("return nil, err\n", nil)
+		sw.Do("} else {\n", nil)
+		sw.Do("patch.Merge($.json$, nested)\n", args)
+		sw.Do("}\n", nil)
+	case uft.Kind == types.Pointer && g.mergePatchMethod(uft.Elem) != "" && fieldEncoding(m) == "":
+		args["method"] = g.mergePatchMethod(uft.Elem)
+		sw.Do("if in.$.name$ == nil {\n", args)
+		sw.Do("if err := patch.Set($.json$, other.$.name$); err != nil {\n", args)
+		sw.Do("return nil, err\n", nil)
+		sw.Do("}\n", nil)
+		sw.Do("} else if nested, err := in.$.name$.$.method$(other.$.name$); err != nil {\n", args)
+		sw.Do("return nil, err\n", nil)
+		sw.Do("} else {\n", nil)
+		sw.Do("patch.Merge($.json$, nested)\n", args)
+		sw.Do("}\n", nil)
+	case uft.Kind == types.Builtin && quoted:
+		sw.Do("if err := patch.SetQuoted($.json$, other.$.name$); err != nil {\n", args)
+		sw.Do("return nil, err\n", nil)
+		sw.Do("}\n", nil)
+	case uft.Kind == types.Builtin, uft.Kind == types.Slice, uft.Kind == types.Pointer && underlyingType(uft.Elem).IsPrimitive(), fieldEncoding(m) != "":
+		args["value"] = "other." + m.Name
+		if fieldEncoding(m) == encodingText && uft.Kind != types.Pointer && uft.Kind != types.Interface {
+			// encoding/json only calls MarshalText methods with a pointer
+			// receiver on addressable values.
+			args["value"] = "&other." + m.Name
+		}
+		sw.Do("if err := patch.Set($.json$, $.value$); err != nil {\n", args)
+		sw.Do("return nil, err\n", nil)
+		sw.Do("}\n", nil)
+	default:
+		sw.Do("if err := patch.Diff($.json$, in.$.name$, other.$.name$); err != nil {\n", args)
+		sw.Do("return nil, err\n", nil)
+		sw.Do("}\n", nil)
+	}
+}
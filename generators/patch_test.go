@@ -0,0 +1,154 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+func Test_mergePatchTypes(t *testing.T) {
+	newStruct := func(name string, comments ...string) *types.Type {
+		return &types.Type{
+			Name:         types.Name{Package: "example.com/api", Name: name},
+			Kind:         types.Struct,
+			CommentLines: comments,
+			Members:      []types.Member{{Name: "Name", Type: types.String}},
+		}
+	}
+	status := newStruct("Status")
+	port := newStruct("Port")
+	spec := newStruct("Spec")
+	root := newStruct("Root", "+deepequal-gen:merge-patch=true")
+	root.Members = []types.Member{
+		{Name: "Status", Type: &types.Type{Kind: types.Pointer, Elem: status}},
+		{Name: "Ports", Type: &types.Type{Kind: types.Slice, Elem: port}},
+		{Name: "Spec", Type: spec, Tags: `deepequal:"json"`},
+	}
+	list := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "List"},
+		Kind:         types.Slice,
+		Elem:         types.String,
+		CommentLines: []string{"+deepequal-gen:merge-patch=true"},
+	}
+
+	testCases := []struct {
+		name      string
+		generated []*types.Type
+		expect    []string
+		errs      []string
+	}{
+		{
+			// Slices are replaced and encoded fields set as a whole, so
+			// only the pointed to struct is patched member by member.
+			name:      "nested structs",
+			generated: []*types.Type{port, root, spec, status},
+			expect:    []string{"example.com/api.Root", "example.com/api.Status"},
+		},
+		{
+			name:      "not a struct",
+			generated: []*types.Type{list},
+			errs:      []string{"type example.com/api.List: deepequal-gen:merge-patch requires a struct type with a generated DeepEqual method"},
+		},
+	}
+
+	for _, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+		for _, t := range tc.generated {
+			policy.generating.Insert(t.Name.String())
+		}
+		patching, errs := mergePatchTypes(tc.generated, policy)
+		if strings.Join(patching.List(), ",") != strings.Join(tc.expect, ",") {
+			t.Errorf("%s: expected merge patch types %v, got %v", tc.name, tc.expect, patching.List())
+		}
+		if got := strings.Join(errs2strings(errs), "\n"); got != strings.Join(tc.errs, "\n") {
+			t.Errorf("%s: expected errors %q, got %q", tc.name, tc.errs, got)
+		}
+	}
+}
+
+func Test_doMemberPatch(t *testing.T) {
+	status := &types.Type{
+		Name:    types.Name{Package: "example.com/api", Name: "Status"},
+		Kind:    types.Struct,
+		Members: []types.Member{{Name: "Message", Type: types.String}},
+	}
+	statusPointer := &types.Type{Kind: types.Pointer, Elem: status}
+
+	testCases := []struct {
+		name   string
+		member types.Member
+		remove bool
+	}{
+		{
+			// Nil pointers to patched structs are encoded as null.
+			name:   "pointer to a patched struct",
+			member: types.Member{Name: "Status", Type: statusPointer, Tags: `json:"status"`},
+			remove: true,
+		},
+		{
+			name:   "pointer to an encoded struct",
+			member: types.Member{Name: "Status", Type: statusPointer, Tags: `json:"status" deepequal:"json"`},
+		},
+		{
+			name:   "omitted empty value",
+			member: types.Member{Name: "Message", Type: types.String, Tags: `json:"message,omitempty"`},
+			remove: true,
+		},
+		{
+			name:   "empty value",
+			member: types.Member{Name: "Message", Type: types.String, Tags: `json:"message"`},
+		},
+		{
+			name:   "omitted empty slice",
+			member: types.Member{Name: "Items", Type: &types.Type{Kind: types.Slice, Elem: types.String}, Tags: `json:"items,omitempty"`},
+			remove: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		policy := newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString("example.com/api"), "ignore_autogenerated")
+		policy.mergePatching.Insert(status.Name.String())
+		g := &genDeepEqual{policy: policy}
+		out := &bytes.Buffer{}
+		sw := generator.NewSnippetWriter(out, &generator.Context{}, "$", "$")
+		g.doMemberPatch(&tc.member, sw)
+		if err := sw.Error(); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		name, _ := jsonFieldName(&tc.member)
+		if remove := strings.Contains(out.String(), fmt.Sprintf("patch.Remove(%q)", name)); remove != tc.remove {
+			t.Errorf("%s: expected removal %t, got:\n%s", tc.name, tc.remove, out.String())
+		}
+	}
+}
+
+func Test_jsonFieldOptions(t *testing.T) {
+	cases := []struct {
+		tags      string
+		omitempty bool
+		quoted    bool
+	}{
+		{tags: ``},
+		{tags: `json:"name"`},
+		{tags: `json:"name,omitempty"`, omitempty: true},
+		{tags: `json:",string"`, quoted: true},
+		{tags: `json:"name,omitempty,string"`, omitempty: true, quoted: true},
+		{tags: `yaml:"name,omitempty"`},
+	}
+	for _, c := range cases {
+		omitempty, quoted := jsonFieldOptions(&types.Member{Name: "Name", Tags: c.tags})
+		if omitempty != c.omitempty || quoted != c.quoted {
+			t.Errorf("%s: expected %v %v, got %v %v", c.tags, c.omitempty, c.quoted, omitempty, quoted)
+		}
+	}
+}
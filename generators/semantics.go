@@ -0,0 +1,53 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"k8s.io/gengo/types"
+)
+
+// Known values for the tagSemanticsTagName tag.
+const (
+	// semanticsGo compares the Go values of the fields, the default.
+	semanticsGo = "go"
+	// semanticsJSON compares struct types like their JSON encodings.
+	semanticsJSON = "json"
+)
+
+func extractSemanticsTypeTag(t *types.Type) *enabledTagValue {
+	return extractTypeOrPackageTag(t, extractSemanticsTag)
+}
+
+func extractSemanticsTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagSemanticsTagName, comments)
+}
+
+// jsonSemantics returns whether struct type t is compared like its JSON
+// encoding: fields which encoding/json leaves out are ignored, and nil slices
+// and maps, encoded as null, only equal empty ones in omitempty fields.
+func jsonSemantics(t *types.Type) bool {
+	tag := extractSemanticsTypeTag(t)
+	return tag != nil && tag.value == semanticsJSON
+}
+
+// jsonOmitsMember returns whether encoding/json leaves member m out of the
+// encoding of its struct.  The fields of embedded structs are promoted, even
+// when the embedded type is unexported.
+func jsonOmitsMember(m *types.Member) bool {
+	name, promoted := jsonFieldName(m)
+	return name == "" && !promoted
+}
+
+// distinguishesNil returns whether the nil and empty values of the slice or
+// map member m of struct type t differ, because t is compared like its JSON
+// encoding and m is encoded as null when nil.
+func distinguishesNil(t *types.Type, m *types.Member) bool {
+	if !jsonSemantics(t) {
+		return false
+	}
+	omitempty, _ := jsonFieldOptions(m)
+	return !omitempty
+}
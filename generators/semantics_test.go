@@ -0,0 +1,84 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_ignoresMemberJSONSemantics(t *testing.T) {
+	inner := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "inner"},
+		Kind: types.Struct,
+	}
+	members := []types.Member{
+		{Name: "Name", Type: types.String, Tags: `json:"name"`},
+		{Name: "Untagged", Type: types.String},
+		{Name: "Skipped", Type: types.String, Tags: `json:"-"`},
+		{Name: "Dash", Type: types.String, Tags: `json:"-,"`},
+		{Name: "cache", Type: types.String},
+		{Name: "inner", Type: inner, Embedded: true},
+	}
+	ignoredJSON := []bool{false, false, true, false, true, false}
+
+	plain := &types.Type{
+		Name:    types.Name{Package: "example.com/api", Name: "Plain"},
+		Kind:    types.Struct,
+		Members: members,
+	}
+	semantic := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Semantic"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:semantics=json"},
+		Members:      members,
+	}
+	for i := range members {
+		m := &members[i]
+		if ignoresMember(plain, m) {
+			t.Errorf("%s: expected the field to be compared without JSON semantics", m.Name)
+		}
+		if got := ignoresMember(semantic, m); got != ignoredJSON[i] {
+			t.Errorf("%s: expected ignored %v with JSON semantics, got %v", m.Name, ignoredJSON[i], got)
+		}
+	}
+
+	// The package default applies to the types without their own tag.
+	installUniverse(types.Universe{"example.com/api": &types.Package{Path: "example.com/api"}})
+	defer installUniverse(nil)
+	headerComments["example.com/api"] = []string{"+deepequal-gen:semantics=json"}
+	if !ignoresMember(plain, &members[2]) {
+		t.Errorf("expected the package default to apply")
+	}
+	semantic.CommentLines = []string{"+deepequal-gen:semantics=go"}
+	if ignoresMember(semantic, &members[2]) {
+		t.Errorf("expected the type tag to override the package default")
+	}
+}
+
+func Test_distinguishesNil(t *testing.T) {
+	semantic := &types.Type{
+		Name:         types.Name{Package: "example.com/api", Name: "Semantic"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+deepequal-gen:semantics=json"},
+	}
+	plain := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Plain"},
+		Kind: types.Struct,
+	}
+	tags := &types.Member{Name: "Tags", Tags: `json:"tags"`}
+	optional := &types.Member{Name: "Tags", Tags: `json:"tags,omitempty"`}
+	if !distinguishesNil(semantic, tags) {
+		t.Errorf("expected nil to differ from empty in a field encoded as null")
+	}
+	if distinguishesNil(semantic, optional) {
+		t.Errorf("expected nil to equal empty in an omitempty field")
+	}
+	if distinguishesNil(plain, tags) {
+		t.Errorf("expected nil to equal empty without JSON semantics")
+	}
+}
@@ -0,0 +1,66 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"k8s.io/gengo/types"
+)
+
+// syncTypes are the types of the sync package which hold synchronization
+// state rather than data.
+var syncTypes = map[string]bool{
+	"Cond":      true,
+	"Mutex":     true,
+	"Once":      true,
+	"Pool":      true,
+	"RWMutex":   true,
+	"WaitGroup": true,
+}
+
+// atomicTypes are the types of the sync/atomic package whose value is read
+// with their Load method.
+var atomicTypes = map[string]bool{
+	"Bool":    true,
+	"Int32":   true,
+	"Int64":   true,
+	"Uint32":  true,
+	"Uint64":  true,
+	"Uintptr": true,
+	"Value":   true,
+}
+
+// isLockType returns whether values of type t are synchronization state
+// which is not part of the value of the enclosing struct: the types of the
+// sync package listed above, sync.Locker, and the empty structs with both Lock
+// and Unlock methods used as noCopy markers for go vet.  Other types with
+// Lock and Unlock methods may hold data, and are compared.
+func isLockType(t *types.Type) bool {
+	if t.Name.Package == "sync" && (syncTypes[t.Name.Name] || t.Name.Name == "Locker") {
+		return true
+	}
+	if t.Kind != types.Struct || len(t.Members) != 0 {
+		return false
+	}
+	_, lock := t.Methods["Lock"]
+	_, unlock := t.Methods["Unlock"]
+	return lock && unlock
+}
+
+// isLockMember returns whether member m holds a lock, or a pointer to one,
+// and is therefore left out of comparisons.
+func isLockMember(m *types.Member) bool {
+	if isLockType(m.Type) {
+		return true
+	}
+	ut := underlyingType(m.Type)
+	return ut.Kind == types.Pointer && isLockType(ut.Elem)
+}
+
+// isAtomicType returns whether type t is a sync/atomic type whose values are
+// compared through their Load method.
+func isAtomicType(t *types.Type) bool {
+	return t.Name.Package == "sync/atomic" && atomicTypes[t.Name.Name]
+}
@@ -0,0 +1,140 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+// TransitiveImports returns the import paths of the packages transitively
+// imported by inputs which are rooted under the bounding dirs, excluding the
+// inputs themselves.  In transitive mode these packages are added to the
+// inputs so that their comment tags are parsed and the types reachable from
+// the inputs can be generated in them.
+func TransitiveImports(inputs, boundingDirs []string) ([]string, error) {
+	seen := sets.NewString()
+	for _, input := range inputs {
+		seen.Insert(strings.TrimSuffix(input, "/..."))
+	}
+
+	result := []string{}
+	queue := []string{}
+	for _, input := range inputs {
+		if !strings.HasSuffix(input, "/...") {
+			queue = append(queue, input)
+		}
+	}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+
+		p, err := build.Default.Import(pkg, ".", 0)
+		if err != nil {
+			if _, noGo := err.(*build.NoGoError); !noGo {
+				return nil, fmt.Errorf("unable to find the imports of %q: %v", pkg, err)
+			}
+		}
+		for _, imported := range p.Imports {
+			if seen.Has(imported) || !isRootedUnder(imported, boundingDirs) {
+				continue
+			}
+			seen.Insert(imported)
+			result = append(result, imported)
+			queue = append(queue, imported)
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
+}
+
+// generatedMethods returns the names, in the form "Type.Method", of the
+// methods declared in the files of package pkg which are excluded by the
+// generatedBuildTag build tag.  These files are not parsed by gengo, so
+// methods previously generated in them are otherwise invisible.
+func generatedMethods(pkg, generatedBuildTag string) sets.String {
+	context := build.Default
+	context.BuildTags = append(append([]string{}, context.BuildTags...), generatedBuildTag)
+
+	methods := sets.NewString()
+	p, err := context.Import(pkg, ".", 0)
+	if err != nil {
+		return methods
+	}
+
+	fset := token.NewFileSet()
+	for _, name := range p.IgnoredGoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(p.Dir, name), nil, 0)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			recv := fn.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				methods.Insert(ident.Name + "." + fn.Name.Name)
+			}
+		}
+	}
+	return methods
+}
+
+// reachableTypes returns the names of the types which must be generated so
+// that the code generated for the roots compiles: every type reachable from
+// the roots through delegated comparisons which lacks a DeepEqual method and
+// is rooted under the bounding dirs.  Reachable types which cannot be
+// compared by any strategy of the policy are reported as errors.
+func reachableTypes(roots []*types.Type, policy *comparisonPolicy) (sets.String, []error) {
+	result := sets.NewString()
+	visited := sets.NewString()
+	for _, root := range roots {
+		visited.Insert(root.Name.String())
+	}
+
+	var errs []error
+	var walk func(t *types.Type, path string)
+	walk = func(t *types.Type, path string) {
+		if !generatesComparison(t) {
+			return
+		}
+		for _, d := range delegates(t) {
+			dt := d.t
+			if visited.Has(dt.Name.String()) {
+				continue
+			}
+			visited.Insert(dt.Name.String())
+
+			c, err := policy.chooseAt(dt, d.path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", delegatePath(path, d.path), err))
+			} else if c == compareGenerated {
+				result.Insert(dt.Name.String())
+				walk(dt, dt.Name.String())
+			}
+		}
+	}
+
+	for _, root := range roots {
+		walk(root, root.Name.String())
+	}
+	return result, errs
+}
@@ -0,0 +1,75 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+func Test_reachableTypes(t *testing.T) {
+	str := types.String
+	nested := &types.Type{
+		Name: types.Name{Package: "example.com/api/dep", Name: "Nested"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Values", Type: &types.Type{Kind: types.Slice, Elem: str}},
+		},
+	}
+	remote := &types.Type{
+		Name: types.Name{Package: "example.com/api/dep", Name: "Remote"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Nested", Type: nested},
+		},
+	}
+	comparable := &types.Type{
+		Name: types.Name{Package: "example.com/api/dep", Name: "Comparable"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Name", Type: str},
+		},
+	}
+	external := &types.Type{
+		Name: types.Name{Package: "example.com/external", Name: "External"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Values", Type: &types.Type{Kind: types.Slice, Elem: str}},
+		},
+	}
+	root := &types.Type{
+		Name: types.Name{Package: "example.com/api", Name: "Root"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Remote", Type: &types.Type{Kind: types.Pointer, Elem: remote}},
+			{Name: "Comparable", Type: comparable},
+			{Name: "External", Type: &types.Type{Kind: types.Slice, Elem: external}},
+		},
+	}
+
+	policy := newComparisonPolicy([]string{"example.com/api"}, FallbackError, sets.NewString(), "ignore_autogenerated")
+	policy.transitive = true
+	reachable, errs := reachableTypes([]*types.Type{root}, policy)
+	if want := []string{"example.com/api/dep.Nested", "example.com/api/dep.Remote"}; strings.Join(reachable.List(), ",") != strings.Join(want, ",") {
+		t.Errorf("expected reachable types %v, got %v", want, reachable.List())
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected a single error, got %v", errs)
+	}
+	if msg := errs[0].Error(); !strings.HasPrefix(msg, "example.com/api.Root.External[*]: ") || !strings.Contains(msg, "add example.com/external to --bounding-dirs") {
+		t.Errorf("unexpected error: %v", msg)
+	}
+
+	// With the reflection fallback the external type is no longer an error.
+	policy = newComparisonPolicy([]string{"example.com/api"}, FallbackReflect, sets.NewString(), "ignore_autogenerated")
+	policy.transitive = true
+	if _, errs := reachableTypes([]*types.Type{root}, policy); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"k8s.io/gengo/types"
+)
+
+func extractNilEqualsEmptyTypeTag(t *types.Type) *enabledTagValue {
+	return extractTypeOrPackageTag(t, extractNilEqualsEmptyTag)
+}
+
+func extractNilEqualsEmptyMemberTag(t *types.Type, m *types.Member) *enabledTagValue {
+	return extractNilEqualsEmptyTag(memberComments(t, m))
+}
+
+func extractNilEqualsEmptyTag(comments []string) *enabledTagValue {
+	return extractSingleValueTag(tagNilEqualsEmptyTagName, comments)
+}
+
+// unstructuredType returns whether values of type t are unstructured: values
+// of the empty interface type, such as the trees encoding/json decodes into
+// interface{} values.  Only the elements of unstructured containers are
+// compared as unstructured values.
+func unstructuredType(t *types.Type) bool {
+	ut := underlyingType(t)
+	return ut.Kind == types.Interface && len(ut.Methods) == 0
+}
+
+// holdsUnstructured returns whether type t, or the type it points to, is an
+// unstructured container: a map or slice of unstructured values.
+func holdsUnstructured(t *types.Type) bool {
+	ut := underlyingType(t)
+	if ut.Kind == types.Pointer {
+		ut = underlyingType(ut.Elem)
+	}
+	return (ut.Kind == types.Slice || ut.Kind == types.Map) && unstructuredType(ut.Elem)
+}
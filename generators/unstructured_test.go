@@ -0,0 +1,58 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func Test_holdsUnstructured(t *testing.T) {
+	empty := &types.Type{Name: types.Name{Name: "interface{}"}, Kind: types.Interface}
+	stringer := &types.Type{
+		Name:    types.Name{Package: "fmt", Name: "Stringer"},
+		Kind:    types.Interface,
+		Methods: map[string]*types.Type{"String": {Kind: types.Func}},
+	}
+	object := &types.Type{
+		Name:       types.Name{Package: "example.com/api", Name: "Object"},
+		Kind:       types.Alias,
+		Underlying: &types.Type{Kind: types.Map, Key: types.String, Elem: empty},
+	}
+	testCases := []struct {
+		t            *types.Type
+		unstructured bool
+		holds        bool
+	}{
+		{empty, true, false},
+		{stringer, false, false},
+		{types.String, false, false},
+		{object, false, true},
+		{&types.Type{Kind: types.Slice, Elem: empty}, false, true},
+		{&types.Type{Kind: types.Pointer, Elem: object}, false, true},
+		{&types.Type{Kind: types.Slice, Elem: stringer}, false, false},
+	}
+	for i, tc := range testCases {
+		if got := unstructuredType(tc.t); got != tc.unstructured {
+			t.Errorf("case[%d]: expected unstructuredType %v, got %v", i, tc.unstructured, got)
+		}
+		if got := holdsUnstructured(tc.t); got != tc.holds {
+			t.Errorf("case[%d]: expected holdsUnstructured %v, got %v", i, tc.holds, got)
+		}
+	}
+
+	policy := newComparisonPolicy([]string{"example.com/api"}, FallbackError, nil, "ignore_autogenerated")
+	if c, err := policy.chooseAt(empty, "Object[*]"); err != nil || c != compareUnstructured {
+		t.Errorf("expected interface{} elements to be compared with %v, got %v, %v", compareUnstructured, c, err)
+	}
+	if _, err := policy.chooseAt(empty, "Value"); err == nil {
+		t.Errorf("expected an error comparing interface{} fields with --fallback=%s", FallbackError)
+	}
+	if _, err := policy.chooseAt(stringer, "List[*]"); err == nil {
+		t.Errorf("expected an error comparing other interfaces with --fallback=%s", FallbackError)
+	}
+}
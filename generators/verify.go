@@ -0,0 +1,77 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/gengo/generator"
+)
+
+// verifyFileType replaces the default Go file type when running with
+// --verify-only.  Rather than stopping at the first difference, it prints a
+// unified diff between every out-of-date file on disk and the output that
+// would have been generated.  Nothing is ever written to disk.
+type verifyFileType struct {
+	*generator.DefaultFileType
+	out io.Writer
+}
+
+func newVerifyFileType(out io.Writer) generator.FileType {
+	return &verifyFileType{
+		DefaultFileType: generator.NewGolangFile(),
+		out:             out,
+	}
+}
+
+func (ft *verifyFileType) VerifyFile(f *generator.File, pathname string) error {
+	b := &bytes.Buffer{}
+	et := generator.NewErrorTracker(b)
+	ft.Assemble(et, f)
+	if et.Error() != nil {
+		return et.Error()
+	}
+	formatted, err := ft.Format(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format the output for %q: %v", pathname, err)
+	}
+
+	existing, err := ioutil.ReadFile(pathname)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("unable to read file %q for comparison: %v", pathname, err)
+	}
+	if bytes.Equal(formatted, existing) {
+		return nil
+	}
+
+	var a []string
+	if len(existing) > 0 {
+		a = difflib.SplitLines(string(existing))
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        difflib.SplitLines(string(formatted)),
+		FromFile: pathname,
+		ToFile:   pathname + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to compare the output for %q: %v", pathname, err)
+	}
+	if _, err := io.WriteString(ft.out, diff); err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return fmt.Errorf("%s is missing", pathname)
+	}
+	return fmt.Errorf("%s is out of date", pathname)
+}
@@ -0,0 +1,86 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package generators
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/generator"
+)
+
+func Test_verifyFileType(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepequal-gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	f := &generator.File{
+		Name:        "zz_generated.go",
+		PackageName: "foo",
+		Header:      []byte("// Code generated by deepequal-gen. DO NOT EDIT.\n\n"),
+	}
+	f.Body.WriteString("func (in *Foo) DeepEqual(other *Foo) bool {\nreturn true\n}\n")
+	expected := "// Code generated by deepequal-gen. DO NOT EDIT.\n\npackage foo\n\nfunc (in *Foo) DeepEqual(other *Foo) bool {\n\treturn true\n}\n"
+
+	testCases := []struct {
+		existing *string
+		diff     []string
+		error    bool
+	}{
+		{
+			existing: nil,
+			diff:     []string{"@@ -0,0 +1,", "+package foo"},
+			error:    true,
+		},
+		{
+			existing: &expected,
+			diff:     nil,
+			error:    false,
+		},
+		{
+			existing: func() *string { s := strings.Replace(expected, "true", "false", 1); return &s }(),
+			diff:     []string{"-\treturn false", "+\treturn true"},
+			error:    true,
+		},
+	}
+
+	for i, tc := range testCases {
+		pathname := filepath.Join(dir, f.Name)
+		os.Remove(pathname)
+		if tc.existing != nil {
+			if err := ioutil.WriteFile(pathname, []byte(*tc.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		out := &bytes.Buffer{}
+		err := newVerifyFileType(out).VerifyFile(f, pathname)
+		if tc.error && err == nil {
+			t.Errorf("case[%d]: expected an error, got none", i)
+		} else if !tc.error && err != nil {
+			t.Errorf("case[%d]: expected no error, got: %v", i, err)
+		}
+		if len(tc.diff) == 0 && out.Len() > 0 {
+			t.Errorf("case[%d]: expected no diff, got:\n%s", i, out.String())
+		}
+		for _, line := range tc.diff {
+			if !strings.Contains(out.String(), line) {
+				t.Errorf("case[%d]: expected diff to contain %q, got:\n%s", i, line, out.String())
+			}
+		}
+		if tc.existing == nil {
+			if _, err := os.Stat(pathname); !os.IsNotExist(err) {
+				t.Errorf("case[%d]: expected %s not to be written", i, pathname)
+			}
+		}
+	}
+}
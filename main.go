@@ -10,6 +10,13 @@ Copyright 2019 Wind River Systems, Inc.
 package main
 
 import (
+	goflag "flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
 	"github.com/wind-river/deepequal-gen/generators"
 	"k8s.io/gengo/args"
 
@@ -30,15 +37,200 @@ func main() {
 		"Comma-separated list of import paths which bound the types for which deep-copies will be generated.")
 	pflag.CommandLine.StringVar(&customArgs.GenPackagePath, "gen-package-path", customArgs.GenPackagePath,
 		"Override generated package path which deep-copies will be generated.")
+	pflag.CommandLine.StringVar(&customArgs.ConfigFile, "config", customArgs.ConfigFile,
+		"YAML file setting generation options by import path and type name, in addition to comment tags.")
+	pflag.CommandLine.BoolVar(&customArgs.Transitive, "transitive", customArgs.Transitive,
+		"Also generate DeepEqual for every type within the bounding dirs which generated code depends on.")
+	pflag.CommandLine.StringVar(&customArgs.Fallback, "fallback", generators.FallbackReflect,
+		"How to compare types outside the bounding dirs without a DeepEqual method which are not comparable with ==: \"reflect\" or \"error\".")
+	pflag.CommandLine.StringVar(&customArgs.InputCacheFile, "input-cache", customArgs.InputCacheFile,
+		"File recording a hash of the inputs of each package; packages whose inputs did not change are not regenerated. Packages outside the bounding dirs are only hashed by location.")
+	pflag.CommandLine.BoolVar(&customArgs.Force, "force", customArgs.Force,
+		"Regenerate every package even if its inputs did not change since the last run.")
 	arguments.CustomArgs = customArgs
 
+	// Parse the flags here rather than in Execute so that the packages listed
+	// in the configuration file can be added to the inputs.
+	arguments.AddFlags(pflag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(goflag.CommandLine)
+	pflag.Parse()
+
+	if len(customArgs.ConfigFile) > 0 {
+		config, err := generators.LoadConfig(customArgs.ConfigFile)
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+		customArgs.Config = config
+		for _, path := range config.PackagePaths() {
+			if !contains(arguments.InputDirs, path) {
+				arguments.InputDirs = append(arguments.InputDirs, path)
+			}
+		}
+	}
+
+	// In transitive mode the types which generated code depends on may live in
+	// other packages within the bounding dirs, which must be parsed as well.
+	var transitiveInputs []string
+	if customArgs.Transitive {
+		if customArgs.BoundingDirs == nil {
+			customArgs.BoundingDirs = defaultBoundingDirs(arguments.InputDirs)
+		}
+		imports, err := generators.TransitiveImports(arguments.InputDirs, trimBoundingDirs(customArgs.BoundingDirs))
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+		transitiveInputs = imports
+		arguments.InputDirs = append(arguments.InputDirs, imports...)
+	}
+
+	// Skip the packages whose inputs did not change since the last run.
+	var cache *generators.InputCache
+	var pending map[string]string
+	if len(customArgs.InputCacheFile) > 0 && !arguments.VerifyOnly {
+		var err error
+		cache, err = generators.LoadInputCache(customArgs.InputCacheFile)
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+		pending = skipUpToDate(arguments, customArgs, cache, transitiveInputs)
+		if len(arguments.InputDirs) == 0 {
+			klog.V(2).Info("All packages are up to date.")
+			return
+		}
+	}
+
 	// Run it.
-	if err := arguments.Execute(
+	if err := arguments.WithoutDefaultFlagParsing().Execute(
 		generators.NameSystems(),
 		generators.DefaultNameSystem(),
 		generators.Packages,
 	); err != nil {
 		klog.Fatalf("Error: %v", err)
 	}
+
+	if cache != nil {
+		for path, hash := range pending {
+			if err := cache.Record(path, hash, outputFile(arguments, customArgs, path)); err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+		}
+		if err := cache.Save(); err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+	}
 	klog.V(2).Info("Completed successfully.")
 }
+
+// skipUpToDate removes the packages whose recorded input hash matches their
+// current one from the inputs, and returns the input hashes of the packages
+// left to generate.  Inputs ending with "/..." stand for packages which are
+// only known once they are loaded, so they are kept without being hashed and
+// always generated.
+func skipUpToDate(arguments *args.GeneratorArgs, customArgs *generators.CustomArgs, cache *generators.InputCache, transitiveInputs []string) map[string]string {
+	if customArgs.BoundingDirs == nil {
+		// Keep the default bounds of all inputs, including skipped ones.
+		customArgs.BoundingDirs = defaultBoundingDirs(arguments.InputDirs)
+	}
+
+	hasher := generators.NewInputHasher(trimBoundingDirs(customArgs.BoundingDirs), arguments.GeneratedBuildTag, generatorOptions(arguments, customArgs)...)
+	pending := map[string]string{}
+	inputs := make([]string, 0, len(arguments.InputDirs))
+	roots := []string{}
+	for _, path := range arguments.InputDirs {
+		if !contains(transitiveInputs, path) && !strings.HasSuffix(path, "/...") {
+			roots = append(roots, path)
+		}
+	}
+	for _, path := range arguments.InputDirs {
+		if strings.HasSuffix(path, "/...") {
+			inputs = append(inputs, path)
+			continue
+		}
+		// The types generated in packages added in transitive mode depend on
+		// every input, not only on the packages they import.
+		hashed := []string{path}
+		if contains(transitiveInputs, path) {
+			hashed = append(hashed, roots...)
+		}
+		hash, err := hasher.Hash(hashed...)
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+		if !customArgs.Force && cache.UpToDate(path, hash, outputFile(arguments, customArgs, path)) {
+			klog.V(3).Infof("Package %q is up to date", path)
+			continue
+		}
+		pending[path] = hash
+		inputs = append(inputs, path)
+	}
+	arguments.InputDirs = inputs
+	return pending
+}
+
+// defaultBoundingDirs returns the bounding dirs used when none are given: the
+// input packages themselves.
+func defaultBoundingDirs(inputs []string) []string {
+	boundingDirs := make([]string, 0, len(inputs))
+	for _, dir := range inputs {
+		boundingDirs = append(boundingDirs, strings.TrimSuffix(dir, "/..."))
+	}
+	return boundingDirs
+}
+
+// trimBoundingDirs strips any trailing slashes from the bounding dirs.
+func trimBoundingDirs(dirs []string) []string {
+	boundingDirs := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		boundingDirs = append(boundingDirs, strings.TrimRight(dir, "/"))
+	}
+	return boundingDirs
+}
+
+// generatorOptions returns everything besides the source code that affects
+// the generated output: the generator itself and its arguments.
+func generatorOptions(arguments *args.GeneratorArgs, customArgs *generators.CustomArgs) [][]byte {
+	options := [][]byte{
+		[]byte(arguments.OutputBase),
+		[]byte(arguments.OutputFileBaseName),
+		[]byte(arguments.GeneratedBuildTag),
+		[]byte(strings.Join(customArgs.BoundingDirs, ",")),
+		[]byte(customArgs.GenPackagePath),
+		[]byte(strconv.FormatBool(customArgs.Transitive)),
+		[]byte(customArgs.Fallback),
+	}
+	for _, path := range []string{arguments.GoHeaderFilePath, customArgs.ConfigFile} {
+		if len(path) == 0 {
+			options = append(options, nil)
+			continue
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			klog.Fatalf("Error: %v", err)
+		}
+		options = append(options, data)
+	}
+	if executable, err := os.Executable(); err == nil {
+		if data, err := ioutil.ReadFile(executable); err == nil {
+			options = append(options, data)
+		}
+	}
+	return options
+}
+
+// outputFile returns the location of the file generated for the package with
+// import path pkg.
+func outputFile(arguments *args.GeneratorArgs, customArgs *generators.CustomArgs, pkg string) string {
+	if len(customArgs.GenPackagePath) > 0 {
+		pkg = customArgs.GenPackagePath
+	}
+	return filepath.Join(arguments.OutputBase, pkg, arguments.OutputFileBaseName+".go")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package changedfields
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedFields(t *testing.T) {
+	three, five := int32(3), int32(5)
+	cases := []struct {
+		name    string
+		in      Ttest
+		other   Ttest
+		changed TtestFieldSet
+	}{
+		{
+			name:  "equal",
+			in:    Ttest{Name: "a", Spec: Spec{Replicas: &three}},
+			other: Ttest{Name: "a", Spec: Spec{Replicas: &three}},
+		},
+		{
+			name:    "top-level field",
+			in:      Ttest{Name: "a"},
+			other:   Ttest{Name: "b"},
+			changed: TtestFieldName,
+		},
+		{
+			name:    "nested field",
+			in:      Ttest{Name: "a", Spec: Spec{Replicas: &three}},
+			other:   Ttest{Name: "b", Spec: Spec{Replicas: &five}},
+			changed: TtestFieldName | TtestFieldSpec,
+		},
+		{
+			name:  "unordered array",
+			in:    Ttest{Tags: []string{"a", "b"}, Spec: Spec{Ports: []Port{{Name: "a"}, {Name: "b"}}}},
+			other: Ttest{Tags: []string{"b", "a"}, Spec: Spec{Ports: []Port{{Name: "b"}, {Name: "a"}}}},
+		},
+		{
+			name:    "nil fields ignored on the receiver",
+			in:      Ttest{},
+			other:   Ttest{Optional: &Port{Name: "a"}},
+			changed: 0,
+		},
+		{
+			name:    "nil fields compared on other",
+			in:      Ttest{Optional: &Port{Name: "a"}},
+			other:   Ttest{},
+			changed: TtestFieldOptional,
+		},
+		{
+			name:    "nil fields ignored on either side",
+			in:      Ttest{Aliases: []string{"a"}},
+			other:   Ttest{},
+			changed: 0,
+		},
+		{
+			name:    "interface",
+			in:      Ttest{Shape: 1},
+			other:   Ttest{Shape: "1"},
+			changed: TtestFieldShape,
+		},
+	}
+	for _, c := range cases {
+		changed := c.in.ChangedFields(&c.other)
+		if changed != c.changed {
+			t.Errorf("%s: expected changed fields %v, got %v", c.name, c.changed.Names(), changed.Names())
+		}
+		if equal := c.in.DeepEqual(&c.other); equal != (changed == 0) {
+			t.Errorf("%s: ChangedFields returned %v but DeepEqual returned %t", c.name, changed.Names(), equal)
+		}
+	}
+}
+
+func TestNestedChangedFields(t *testing.T) {
+	three, five := int32(3), int32(5)
+	x := Ttest{Spec: Spec{Replicas: &three, Labels: map[string]string{"a": "b"}}}
+	y := Ttest{Spec: Spec{Replicas: &five, Labels: map[string]string{"a": "b"}}}
+	if changed := x.ChangedFields(&y); !changed.Has(TtestFieldSpec) {
+		t.Fatalf("expected Spec to differ, got %v", changed.Names())
+	}
+	if changed := x.Spec.ChangedFields(&y.Spec); changed != SpecFieldReplicas {
+		t.Errorf("expected only Spec.Replicas to differ, got %v", changed.Names())
+	}
+}
+
+func TestFieldSet(t *testing.T) {
+	set := TtestFieldName | TtestFieldTags
+	if !set.Has(TtestFieldName) || !set.Has(TtestFieldName|TtestFieldTags) || set.Has(TtestFieldName|TtestFieldSpec) {
+		t.Errorf("unexpected Has results for %v", set.Names())
+	}
+	if names := set.Names(); !reflect.DeepEqual(names, []string{"Name", "Tags"}) {
+		t.Errorf("unexpected names %v", names)
+	}
+}
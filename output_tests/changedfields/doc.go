@@ -0,0 +1,9 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.
+package changedfields
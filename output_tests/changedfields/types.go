@@ -0,0 +1,32 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package changedfields
+
+type Port struct {
+	Name   string
+	Number int32
+}
+
+type Spec struct {
+	Replicas *int32
+	// +deepequal-gen:unordered-array=true
+	Ports  []Port
+	Labels map[string]string
+}
+
+// +deepequal-gen:changed-fields=true
+// +deepequal-gen:ignore-nil-fields=true
+type Ttest struct {
+	Name     string
+	Spec     Spec
+	Optional *Port
+	Owner    *Port
+	// +deepequal-gen:unordered-array=true
+	Tags []string
+	// +deepequal-gen:ignore-nil-fields=either
+	Aliases []string
+	Shape   interface{}
+}
@@ -0,0 +1,473 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package changedfields
+
+import (
+	reflect "reflect"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Port) DeepEqual(other *Port) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Number != other.Number {
+		return false
+	}
+
+	return true
+}
+
+// PortFieldSet is a set of fields of Port.
+type PortFieldSet uint64
+
+// Fields of Port in a PortFieldSet.
+const (
+	PortFieldName PortFieldSet = 1 << iota
+	PortFieldNumber
+)
+
+// Has returns whether s holds all the fields in fields.
+func (s PortFieldSet) Has(fields PortFieldSet) bool {
+	return s&fields == fields
+}
+
+// Names returns the names of the fields in s, in declaration order.
+func (s PortFieldSet) Names() []string {
+	names := []string{}
+	if s&PortFieldName != 0 {
+		names = append(names, "Name")
+	}
+	if s&PortFieldNumber != 0 {
+		names = append(names, "Number")
+	}
+	return names
+}
+
+// ChangedFields is an autogenerated deepequal function, returning the fields of
+// the receiver which differ from those of other when compared like DeepEqual.
+// in and other must be non-nil.
+func (in *Port) ChangedFields(other *Port) PortFieldSet {
+	var changed PortFieldSet
+
+	if in.Name != other.Name {
+		changed |= PortFieldName
+	}
+	if in.Number != other.Number {
+		changed |= PortFieldNumber
+	}
+
+	return changed
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Spec) DeepEqual(other *Spec) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Replicas == nil) != (other.Replicas == nil) {
+		return false
+	} else if in.Replicas != nil {
+		if *in.Replicas != *other.Replicas {
+			return false
+		}
+	}
+
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement.DeepEqual(&otherElement) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// SpecFieldSet is a set of fields of Spec.
+type SpecFieldSet uint64
+
+// Fields of Spec in a SpecFieldSet.
+const (
+	SpecFieldReplicas SpecFieldSet = 1 << iota
+	SpecFieldPorts
+	SpecFieldLabels
+)
+
+// Has returns whether s holds all the fields in fields.
+func (s SpecFieldSet) Has(fields SpecFieldSet) bool {
+	return s&fields == fields
+}
+
+// Names returns the names of the fields in s, in declaration order.
+func (s SpecFieldSet) Names() []string {
+	names := []string{}
+	if s&SpecFieldReplicas != 0 {
+		names = append(names, "Replicas")
+	}
+	if s&SpecFieldPorts != 0 {
+		names = append(names, "Ports")
+	}
+	if s&SpecFieldLabels != 0 {
+		names = append(names, "Labels")
+	}
+	return names
+}
+
+// ChangedFields is an autogenerated deepequal function, returning the fields of
+// the receiver which differ from those of other when compared like DeepEqual.
+// in and other must be non-nil.
+func (in *Spec) ChangedFields(other *Spec) SpecFieldSet {
+	var changed SpecFieldSet
+
+	if !func() bool {
+		if (in.Replicas == nil) != (other.Replicas == nil) {
+			return false
+		} else if in.Replicas != nil {
+			if *in.Replicas != *other.Replicas {
+				return false
+			}
+		}
+		return true
+	}() {
+		changed |= SpecFieldReplicas
+	}
+	if !func() bool {
+		if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+			in, other := &in.Ports, &other.Ports
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for _, inElement := range *in {
+					found := false
+					for _, otherElement := range *other {
+						if inElement.DeepEqual(&otherElement) {
+							found = true
+							break
+						}
+					}
+					if !found {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= SpecFieldPorts
+	}
+	if !func() bool {
+		if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+			in, other := &in.Labels, &other.Labels
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for key, inValue := range *in {
+					if otherValue, present := (*other)[key]; !present {
+						return false
+					} else {
+						if inValue != otherValue {
+							return false
+						}
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= SpecFieldLabels
+	}
+
+	return changed
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if in.Optional != nil {
+		if (in.Optional == nil) != (other.Optional == nil) {
+			return false
+		} else if in.Optional != nil {
+			if !in.Optional.DeepEqual(other.Optional) {
+				return false
+			}
+		}
+	}
+
+	if in.Owner != nil {
+		if (in.Owner == nil) != (other.Owner == nil) {
+			return false
+		} else if in.Owner != nil {
+			if !in.Owner.DeepEqual(other.Owner) {
+				return false
+			}
+		}
+	}
+
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement == otherElement {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if in.Aliases != nil && other.Aliases != nil {
+		in, other := &in.Aliases, &other.Aliases
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(in.Shape, other.Shape) {
+		return false
+	}
+
+	return true
+}
+
+// TtestFieldSet is a set of fields of Ttest.
+type TtestFieldSet uint64
+
+// Fields of Ttest in a TtestFieldSet.
+const (
+	TtestFieldName TtestFieldSet = 1 << iota
+	TtestFieldSpec
+	TtestFieldOptional
+	TtestFieldOwner
+	TtestFieldTags
+	TtestFieldAliases
+	TtestFieldShape
+)
+
+// Has returns whether s holds all the fields in fields.
+func (s TtestFieldSet) Has(fields TtestFieldSet) bool {
+	return s&fields == fields
+}
+
+// Names returns the names of the fields in s, in declaration order.
+func (s TtestFieldSet) Names() []string {
+	names := []string{}
+	if s&TtestFieldName != 0 {
+		names = append(names, "Name")
+	}
+	if s&TtestFieldSpec != 0 {
+		names = append(names, "Spec")
+	}
+	if s&TtestFieldOptional != 0 {
+		names = append(names, "Optional")
+	}
+	if s&TtestFieldOwner != 0 {
+		names = append(names, "Owner")
+	}
+	if s&TtestFieldTags != 0 {
+		names = append(names, "Tags")
+	}
+	if s&TtestFieldAliases != 0 {
+		names = append(names, "Aliases")
+	}
+	if s&TtestFieldShape != 0 {
+		names = append(names, "Shape")
+	}
+	return names
+}
+
+// ChangedFields is an autogenerated deepequal function, returning the fields of
+// the receiver which differ from those of other when compared like DeepEqual.
+// in and other must be non-nil.
+func (in *Ttest) ChangedFields(other *Ttest) TtestFieldSet {
+	var changed TtestFieldSet
+
+	if in.Name != other.Name {
+		changed |= TtestFieldName
+	}
+	if !func() bool {
+		if !in.Spec.DeepEqual(&other.Spec) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldSpec
+	}
+	if !func() bool {
+		if in.Optional != nil {
+			if (in.Optional == nil) != (other.Optional == nil) {
+				return false
+			} else if in.Optional != nil {
+				if !in.Optional.DeepEqual(other.Optional) {
+					return false
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= TtestFieldOptional
+	}
+	if !func() bool {
+		if in.Owner != nil {
+			if (in.Owner == nil) != (other.Owner == nil) {
+				return false
+			} else if in.Owner != nil {
+				if !in.Owner.DeepEqual(other.Owner) {
+					return false
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= TtestFieldOwner
+	}
+	if !func() bool {
+		if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+			in, other := &in.Tags, &other.Tags
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for _, inElement := range *in {
+					found := false
+					for _, otherElement := range *other {
+						if inElement == otherElement {
+							found = true
+							break
+						}
+					}
+					if !found {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= TtestFieldTags
+	}
+	if !func() bool {
+		if in.Aliases != nil && other.Aliases != nil {
+			in, other := &in.Aliases, &other.Aliases
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for i, inElement := range *in {
+					if inElement != (*other)[i] {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= TtestFieldAliases
+	}
+	if !func() bool {
+		if !reflect.DeepEqual(in.Shape, other.Shape) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldShape
+	}
+
+	return changed
+}
@@ -0,0 +1,67 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package config
+
+import (
+	"testing"
+)
+
+func TestConfiguredOptions(t *testing.T) {
+	name := "name"
+	x := Ttest{
+		Items:       []string{"a", "b"},
+		Ordered:     []string{"a", "b"},
+		List:        List{"a", "b"},
+		OrderedList: OrderedList{"a", "b"},
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(y *Ttest)
+		expect bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(y *Ttest) {},
+			expect: true,
+		},
+		{
+			name:   "ignored nil field",
+			mutate: func(y *Ttest) { y.Name = &name },
+			expect: true,
+		},
+		{
+			name:   "unordered field",
+			mutate: func(y *Ttest) { y.Items = []string{"b", "a"} },
+			expect: true,
+		},
+		{
+			name:   "ordered field",
+			mutate: func(y *Ttest) { y.Ordered = []string{"b", "a"} },
+			expect: false,
+		},
+		{
+			name:   "unordered type",
+			mutate: func(y *Ttest) { y.List = List{"b", "a"} },
+			expect: true,
+		},
+		{
+			name:   "source tag overridden by config",
+			mutate: func(y *Ttest) { y.OrderedList = OrderedList{"b", "a"} },
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			y := x
+			tc.mutate(&y)
+			if r := x.DeepEqual(&y); r != tc.expect {
+				t.Errorf("expected %t, got %t", tc.expect, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// This is a test package.  Its generation options are set by the
+// output_tests/deepequal.yaml configuration file rather than comment tags.
+package config
+
+type List []string
+
+// The configuration file overrides this tag.
+// +deepequal-gen:unordered-array=true
+type OrderedList []string
+
+type Ttest struct {
+	Name        *string
+	Items       []string
+	Ordered     []string
+	List        List
+	OrderedList OrderedList
+}
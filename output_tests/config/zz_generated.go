@@ -0,0 +1,130 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package config
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *List) DeepEqual(other *List) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for _, inElement := range *in {
+			found := false
+			for _, otherElement := range *other {
+				if inElement == otherElement {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *OrderedList) DeepEqual(other *OrderedList) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for i, inElement := range *in {
+			if inElement != (*other)[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != nil {
+		if (in.Name == nil) != (other.Name == nil) {
+			return false
+		} else if in.Name != nil {
+			if *in.Name != *other.Name {
+				return false
+			}
+		}
+	}
+
+	if ((in.Items != nil) && (other.Items != nil)) || ((in.Items == nil) != (other.Items == nil)) {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement == otherElement {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Ordered != nil) && (other.Ordered != nil)) || ((in.Ordered == nil) != (other.Ordered == nil)) {
+		in, other := &in.Ordered, &other.Ordered
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.List != nil) && (other.List != nil)) || ((in.List == nil) != (other.List == nil)) {
+		in, other := &in.List, &other.List
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	if ((in.OrderedList != nil) && (other.OrderedList != nil)) || ((in.OrderedList == nil) != (other.OrderedList == nil)) {
+		in, other := &in.OrderedList, &other.OrderedList
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,79 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package cycles
+
+import (
+	"testing"
+)
+
+// tree returns a root with two children pointing back at it, and an edge
+// from the first child to the second.
+func tree(names ...string) *Node {
+	root := &Node{Name: names[0]}
+	for _, name := range names[1:] {
+		root.Children = append(root.Children, &Node{Name: name, Parent: root})
+	}
+	first, second := root.Children[0], root.Children[1]
+	first.Edges = []Edge{{Weight: 1, To: second}}
+	second.Edges = []Edge{{Weight: 2, To: root}}
+	first.Peers, second.Peers = Group{second}, Group{first}
+	return root
+}
+
+func TestCycleSafe(t *testing.T) {
+	a, b := tree("root", "a", "b"), tree("root", "a", "b")
+	if !a.DeepEqual(b) {
+		t.Errorf("expected equal cyclic graphs to be equal")
+	}
+	if !a.DeepEqual(a) {
+		t.Errorf("expected a cyclic graph to equal itself")
+	}
+
+	c := tree("root", "a", "c")
+	if a.DeepEqual(c) {
+		t.Errorf("expected different cyclic graphs to differ")
+	}
+
+	d := tree("root", "a", "b")
+	d.Children[1].Edges[0].Weight = 3
+	if a.DeepEqual(d) {
+		t.Errorf("expected cyclic graphs with different edges to differ")
+	}
+}
+
+func TestNotCycleSafe(t *testing.T) {
+	a, b := &List{Value: 1}, &List{Value: 1}
+	a.Next, b.Next = &List{Value: 2}, &List{Value: 2}
+	if !a.DeepEqual(b) {
+		t.Errorf("expected equal lists to be equal")
+	}
+}
+
+func TestCycleSafeUnordered(t *testing.T) {
+	a, b := tree("root", "a", "b"), tree("root", "a", "b")
+	a.Links = []Edge{{Weight: 1, To: a}, {Weight: 2, To: a.Children[0]}}
+	b.Links = []Edge{{Weight: 2, To: b.Children[0]}, {Weight: 1, To: b}}
+	if !a.DeepEqual(b) {
+		t.Errorf("expected unordered links in any order to be equal")
+	}
+
+	// The second pair of elements differs after the first pair matched.
+	b.Links = []Edge{{Weight: 1, To: b}, {Weight: 3, To: b.Children[0]}}
+	if a.DeepEqual(b) {
+		t.Errorf("expected different unordered links to differ")
+	}
+}
+
+func TestNestedNotCycleSafe(t *testing.T) {
+	// Types nested in a cycle-safe type keep their plain DeepEqual method.
+	a, b := &Edge{Weight: 1}, &Edge{Weight: 1}
+	if allocs := testing.AllocsPerRun(100, func() { a.DeepEqual(b) }); allocs != 0 {
+		t.Errorf("expected Edge.DeepEqual not to allocate a visited set, got %v allocations", allocs)
+	}
+	if !a.DeepEqual(b) {
+		t.Errorf("expected equal edges to be equal")
+	}
+}
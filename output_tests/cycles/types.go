@@ -0,0 +1,29 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package cycles
+
+// +deepequal-gen:cycle-safe=true
+type Node struct {
+	Name     string
+	Parent   *Node
+	Children []*Node
+	Edges    []Edge
+	Peers    Group
+	// +deepequal-gen:unordered-array=true
+	Links []Edge
+}
+
+type Group []*Node
+
+type Edge struct {
+	Weight int
+	To     *Node
+}
+
+type List struct {
+	Value int
+	Next  *List
+}
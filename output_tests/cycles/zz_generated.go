@@ -0,0 +1,248 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package cycles
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Edge) DeepEqual(other *Edge) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Weight != other.Weight {
+		return false
+	}
+	if (in.To == nil) != (other.To == nil) {
+		return false
+	} else if in.To != nil {
+		if !in.To.DeepEqual(other.To) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqualVisited is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual.  visited records the pairs of values
+// already being compared, which are considered equal when they are reached
+// again through a cycle.  Pairs found to differ are removed, so that they
+// are not considered equal when an unordered slice compares them again.
+// in must be non-nil.
+func (in *Edge) DeepEqualVisited(other *Edge, visited map[interface{}]bool) (equal bool) {
+	key := [2]*Edge{in, other}
+	if visited[key] {
+		return true
+	}
+	visited[key] = true
+	defer func() {
+		if !equal {
+			delete(visited, key)
+		}
+	}()
+
+	if other == nil {
+		return false
+	}
+
+	if in.Weight != other.Weight {
+		return false
+	}
+	if (in.To == nil) != (other.To == nil) {
+		return false
+	} else if in.To != nil {
+		if !in.To.DeepEqualVisited(other.To, visited) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Group) DeepEqual(other *Group) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for i, inElement := range *in {
+			if !inElement.DeepEqual((*other)[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqualVisited is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual.  visited records the pairs of values
+// already being compared, which are considered equal when they are reached
+// again through a cycle.  Pairs found to differ are removed, so that they
+// are not considered equal when an unordered slice compares them again.
+// in must be non-nil.
+func (in *Group) DeepEqualVisited(other *Group, visited map[interface{}]bool) (equal bool) {
+	key := [2]*Group{in, other}
+	if visited[key] {
+		return true
+	}
+	visited[key] = true
+	defer func() {
+		if !equal {
+			delete(visited, key)
+		}
+	}()
+
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for i, inElement := range *in {
+			if !inElement.DeepEqualVisited((*other)[i], visited) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *List) DeepEqual(other *List) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Value != other.Value {
+		return false
+	}
+	if (in.Next == nil) != (other.Next == nil) {
+		return false
+	} else if in.Next != nil {
+		if !in.Next.DeepEqual(other.Next) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Node) DeepEqual(other *Node) bool {
+	return in.DeepEqualVisited(other, map[interface{}]bool{})
+}
+
+// DeepEqualVisited is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual.  visited records the pairs of values
+// already being compared, which are considered equal when they are reached
+// again through a cycle.  Pairs found to differ are removed, so that they
+// are not considered equal when an unordered slice compares them again.
+// in must be non-nil.
+func (in *Node) DeepEqualVisited(other *Node, visited map[interface{}]bool) (equal bool) {
+	key := [2]*Node{in, other}
+	if visited[key] {
+		return true
+	}
+	visited[key] = true
+	defer func() {
+		if !equal {
+			delete(visited, key)
+		}
+	}()
+
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if (in.Parent == nil) != (other.Parent == nil) {
+		return false
+	} else if in.Parent != nil {
+		if !in.Parent.DeepEqualVisited(other.Parent, visited) {
+			return false
+		}
+	}
+
+	if ((in.Children != nil) && (other.Children != nil)) || ((in.Children == nil) != (other.Children == nil)) {
+		in, other := &in.Children, &other.Children
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqualVisited((*other)[i], visited) {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Edges != nil) && (other.Edges != nil)) || ((in.Edges == nil) != (other.Edges == nil)) {
+		in, other := &in.Edges, &other.Edges
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i := range *in {
+				if !(*in)[i].DeepEqualVisited(&(*other)[i], visited) {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Peers != nil) && (other.Peers != nil)) || ((in.Peers == nil) != (other.Peers == nil)) {
+		in, other := &in.Peers, &other.Peers
+		if other == nil || !in.DeepEqualVisited(other, visited) {
+			return false
+		}
+	}
+
+	if ((in.Links != nil) && (other.Links != nil)) || ((in.Links == nil) != (other.Links == nil)) {
+		in, other := &in.Links, &other.Links
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i := range *in {
+				found := false
+				for j := range *other {
+					if (*in)[i].DeepEqualVisited(&(*other)[j], visited) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package defaults
+
+import (
+	"testing"
+)
+
+func TestDefaults(t *testing.T) {
+	age := 1
+	newTtest := func() Ttest {
+		return Ttest{
+			Items:        []string{"a", "b"},
+			OrderedItems: []string{"a", "b"},
+			List:         List{"a", "b"},
+			OrderedList:  OrderedList{"a", "b"},
+			Age:          &age,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(x, y *Ttest)
+		equal  bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(x, y *Ttest) {},
+			equal:  true,
+		},
+		{
+			name:   "reordered unnamed slice",
+			mutate: func(x, y *Ttest) { y.Items = []string{"b", "a"} },
+			equal:  true,
+		},
+		{
+			name:   "reordered unnamed slice with field override",
+			mutate: func(x, y *Ttest) { y.OrderedItems = []string{"b", "a"} },
+			equal:  false,
+		},
+		{
+			name:   "reordered named slice",
+			mutate: func(x, y *Ttest) { y.List = List{"b", "a"} },
+			equal:  true,
+		},
+		{
+			name:   "reordered named slice with type override",
+			mutate: func(x, y *Ttest) { y.OrderedList = OrderedList{"b", "a"} },
+			equal:  false,
+		},
+		{
+			name:   "nil field ignored",
+			mutate: func(x, y *Ttest) { x.Age = nil },
+			equal:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		x, y := newTtest(), newTtest()
+		tc.mutate(&x, &y)
+		if got := x.DeepEqual(&y); got != tc.equal {
+			t.Errorf("%s: expected DeepEqual %t, got %t", tc.name, tc.equal, got)
+		}
+	}
+
+	strict := Strict{}
+	if strict.DeepEqual(&Strict{Age: &age}) {
+		t.Errorf("expected the type level tag to override the package default")
+	}
+}
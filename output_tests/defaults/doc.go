@@ -0,0 +1,12 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+// +deepequal-gen:unordered-array=true
+// +deepequal-gen:ignore-nil-fields=true
+
+// This is a test package.  Its slices are unordered and its nil fields are
+// ignored by default, unless a type or field overrides the default.
+package defaults
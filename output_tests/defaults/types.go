@@ -0,0 +1,25 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package defaults
+
+type List []string
+
+// +deepequal-gen:unordered-array=false
+type OrderedList []string
+
+type Ttest struct {
+	Items []string
+	// +deepequal-gen:unordered-array=false
+	OrderedItems []string
+	List         List
+	OrderedList  OrderedList
+	Age          *int
+}
+
+// +deepequal-gen:ignore-nil-fields=false
+type Strict struct {
+	Age *int
+}
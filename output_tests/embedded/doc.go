@@ -0,0 +1,11 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.  Embedded fields are compared like named fields of
+// the same type, named after it, and unexported fields are compared unless
+// the ignore-unexported-fields tag is set.
+package embedded
@@ -0,0 +1,114 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package embedded
+
+import (
+	"testing"
+)
+
+func TestEmbedded(t *testing.T) {
+	testCases := []struct {
+		name  string
+		equal func() bool
+		want  bool
+	}{
+		{
+			name: "struct",
+			equal: func() bool {
+				x, y := EmbedStruct{Base{Labels: []string{"a"}}}, EmbedStruct{Base{Labels: []string{"a"}}}
+				return x.DeepEqual(&y)
+			},
+			want: true,
+		},
+		{
+			name: "struct differs",
+			equal: func() bool {
+				x, y := EmbedStruct{Base{Labels: []string{"a"}}}, EmbedStruct{Base{Labels: []string{"b"}}}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+		{
+			name: "pointer",
+			equal: func() bool {
+				x, y := EmbedPointer{&Base{Labels: []string{"a"}}}, EmbedPointer{&Base{Labels: []string{"a"}}}
+				return x.DeepEqual(&y)
+			},
+			want: true,
+		},
+		{
+			name: "nil pointer",
+			equal: func() bool {
+				x, y := EmbedPointer{&Base{}}, EmbedPointer{}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+		{
+			name: "alias",
+			equal: func() bool {
+				x, y := EmbedAlias{Names{"a"}}, EmbedAlias{Names{"b"}}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+		{
+			name: "interface",
+			equal: func() bool {
+				x, y := EmbedInterface{square(2)}, EmbedInterface{square(2)}
+				return x.DeepEqual(&y)
+			},
+			want: true,
+		},
+		{
+			name: "interface differs",
+			equal: func() bool {
+				x, y := EmbedInterface{square(2)}, EmbedInterface{square(3)}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+		{
+			name: "private embedded",
+			equal: func() bool {
+				x, y := EmbedPrivate{inner: inner{Values: []int{1}}}, EmbedPrivate{inner: inner{Values: []int{2}}}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+		{
+			name: "unexported field",
+			equal: func() bool {
+				x, y := EmbedPrivate{count: 1}, EmbedPrivate{count: 2}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+		{
+			name: "ignored unexported fields",
+			equal: func() bool {
+				x := IgnoreUnexported{inner: inner{Values: []int{1}}, count: 1, Name: "a"}
+				y := IgnoreUnexported{inner: inner{Values: []int{2}}, count: 2, Name: "a"}
+				return x.DeepEqual(&y)
+			},
+			want: true,
+		},
+		{
+			name: "ignored unexported fields with exported field differing",
+			equal: func() bool {
+				x, y := IgnoreUnexported{Name: "a"}, IgnoreUnexported{Name: "b"}
+				return x.DeepEqual(&y)
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.equal(); got != tc.want {
+			t.Errorf("%s: expected DeepEqual %t, got %t", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package embedded
+
+type Base struct {
+	Labels []string
+}
+
+type Names []string
+
+type Shape interface {
+	Area() int
+}
+
+type square int
+
+func (s square) Area() int {
+	return int(s) * int(s)
+}
+
+type inner struct {
+	Values []int
+}
+
+type EmbedStruct struct {
+	Base
+}
+
+type EmbedPointer struct {
+	*Base
+}
+
+type EmbedAlias struct {
+	Names
+}
+
+type EmbedInterface struct {
+	Shape
+}
+
+type EmbedPrivate struct {
+	inner
+	count int
+	Name  string
+}
+
+// +deepequal-gen:ignore-unexported-fields=true
+type IgnoreUnexported struct {
+	inner
+	count int
+	Name  string
+}
@@ -0,0 +1,194 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package embedded
+
+import (
+	reflect "reflect"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Base) DeepEqual(other *Base) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *EmbedAlias) DeepEqual(other *EmbedAlias) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Names != nil) && (other.Names != nil)) || ((in.Names == nil) != (other.Names == nil)) {
+		in, other := &in.Names, &other.Names
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *EmbedInterface) DeepEqual(other *EmbedInterface) bool {
+	if other == nil {
+		return false
+	}
+
+	if !reflect.DeepEqual(in.Shape, other.Shape) {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *EmbedPointer) DeepEqual(other *EmbedPointer) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Base == nil) != (other.Base == nil) {
+		return false
+	} else if in.Base != nil {
+		if !in.Base.DeepEqual(other.Base) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *EmbedPrivate) DeepEqual(other *EmbedPrivate) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.inner.deepEqual(&other.inner) {
+		return false
+	}
+
+	if in.count != other.count {
+		return false
+	}
+	if in.Name != other.Name {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *EmbedStruct) DeepEqual(other *EmbedStruct) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.Base.DeepEqual(&other.Base) {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *IgnoreUnexported) DeepEqual(other *IgnoreUnexported) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+
+	return true
+}
+
+// deepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *inner) deepEqual(other *inner) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Values != nil) && (other.Values != nil)) || ((in.Values == nil) != (other.Values == nil)) {
+		in, other := &in.Values, &other.Values
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Names) DeepEqual(other *Names) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for i, inElement := range *in {
+			if inElement != (*other)[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,155 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package encodedfields
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestEncodedFields(t *testing.T) {
+	now := time.Now()
+	negative, positive := Level(-1), Level(1)
+	cases := []struct {
+		name  string
+		in    Ttest
+		other Ttest
+		equal bool
+	}{
+		{
+			name:  "json whitespace and key order",
+			in:    Ttest{Raw: []byte(`{"a": 1, "b": [true, null]}`)},
+			other: Ttest{Raw: []byte(`{"b":[true,null],"a":1}`)},
+			equal: true,
+		},
+		{
+			name:  "json number notation",
+			in:    Ttest{Document: `{"replicas": 1e2}`},
+			other: Ttest{Document: `{"replicas": 100.0}`},
+			equal: true,
+		},
+		{
+			name:  "json values",
+			in:    Ttest{Raw: []byte(`{"a": 1}`)},
+			other: Ttest{Raw: []byte(`{"a": 2}`)},
+		},
+		{
+			name:  "large json numbers",
+			in:    Ttest{Raw: []byte(`9007199254740993`)},
+			other: Ttest{Raw: []byte(`9007199254740992`)},
+		},
+		{
+			name:  "invalid json compared as bytes",
+			in:    Ttest{Raw: []byte(`{"a": `)},
+			other: Ttest{Raw: []byte(`{"a":`)},
+		},
+		{
+			name:  "identical invalid json",
+			in:    Ttest{Raw: []byte(`{"a": `)},
+			other: Ttest{Raw: []byte(`{"a": `)},
+			equal: true,
+		},
+		{
+			name:  "trailing data is invalid",
+			in:    Ttest{Raw: []byte(`1 2`)},
+			other: Ttest{Raw: []byte(`1 3`)},
+		},
+		{
+			name:  "yaml formatting",
+			in:    Ttest{Manifest: []byte("kind: Pod\nmetadata:\n  name: a\n")},
+			other: Ttest{Manifest: []byte("metadata: {name: a}\nkind: Pod")},
+			equal: true,
+		},
+		{
+			name:  "yaml values",
+			in:    Ttest{Manifest: []byte("kind: Pod\n")},
+			other: Ttest{Manifest: []byte("kind: Service\n")},
+		},
+		{
+			name:  "text ignores what it does not encode",
+			in:    Ttest{Version: Version{Major: 1, Minor: 2, Build: "a"}},
+			other: Ttest{Version: Version{Major: 1, Minor: 2, Build: "b"}},
+			equal: true,
+		},
+		{
+			name:  "text values",
+			in:    Ttest{Version: Version{Major: 1, Minor: 2}},
+			other: Ttest{Version: Version{Major: 1, Minor: 3}},
+		},
+		{
+			name:  "ip representations",
+			in:    Ttest{Address: net.IPv4(10, 0, 0, 1)},
+			other: Ttest{Address: net.IPv4(10, 0, 0, 1).To4()},
+			equal: true,
+		},
+		{
+			name:  "monotonic clock reading",
+			in:    Ttest{Stamp: now},
+			other: Ttest{Stamp: now.Round(0)},
+			equal: true,
+		},
+		{
+			name:  "nil pointer",
+			in:    Ttest{Limit: &positive},
+			other: Ttest{},
+		},
+		{
+			name:  "text errors compared by value",
+			in:    Ttest{Level: negative, Limit: &negative},
+			other: Ttest{Level: Level(-1), Limit: &negative},
+			equal: true,
+		},
+		{
+			name:  "text errors on one side",
+			in:    Ttest{Level: negative},
+			other: Ttest{Level: positive},
+		},
+	}
+	for _, c := range cases {
+		if got := c.in.DeepEqual(&c.other); got != c.equal {
+			t.Errorf("%s: expected DeepEqual %v, got %v", c.name, c.equal, got)
+		}
+		if got := c.in.ChangedFields(&c.other) == 0; got != c.equal {
+			t.Errorf("%s: expected no changed fields %v, got %v", c.name, c.equal, c.in.ChangedFields(&c.other).Names())
+		}
+		if c.equal {
+			if patch, err := c.in.DeepMergePatch(&c.other); err != nil || string(patch) != "{}" {
+				t.Errorf("%s: expected an empty merge patch, got %s, %v", c.name, patch, err)
+			}
+		}
+	}
+}
+
+func TestEncodedFieldsMatch(t *testing.T) {
+	actual := Ttest{
+		Raw:     []byte(`{"a": 1, "b": 2}`),
+		Version: Version{Major: 1, Minor: 2, Build: "a"},
+		Stamp:   time.Now(),
+	}
+	if !(&Ttest{}).DeepMatches(&actual) {
+		t.Errorf("expected zero fields to match any value")
+	}
+	if !(&Ttest{Raw: []byte(`{"b":2,"a":1}`), Version: Version{Major: 1, Minor: 2}}).DeepMatches(&actual) {
+		t.Errorf("expected fields with equal encodings to match")
+	}
+	if (&Ttest{Raw: []byte(`{"a":1}`)}).DeepMatches(&actual) {
+		t.Errorf("expected fields with different encodings not to match")
+	}
+}
+
+func TestEncodedFieldsMergePatch(t *testing.T) {
+	level := Level(2)
+	in := Ttest{Version: Version{Major: 1}, Level: 1}
+	modified := Ttest{Version: Version{Major: 2}, Level: 2, Limit: &level}
+	patch, err := in.DeepMergePatch(&modified)
+	if err != nil {
+		t.Fatalf("DeepMergePatch() failed: %v", err)
+	}
+	if expected := `{"level":"level-2","limit":"level-2","version":"2.0"}`; string(patch) != expected {
+		t.Errorf("expected %s, got %s", expected, patch)
+	}
+}
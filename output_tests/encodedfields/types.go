@@ -0,0 +1,49 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package encodedfields
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+type Version struct {
+	Major int
+	Minor int
+	Build string
+}
+
+// MarshalText leaves out the build of the version.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%d", v.Major, v.Minor)), nil
+}
+
+type Level int
+
+func (l *Level) MarshalText() ([]byte, error) {
+	if *l < 0 {
+		return nil, errors.New("negative level")
+	}
+	return []byte(fmt.Sprintf("level-%d", int(*l))), nil
+}
+
+// +deepequal-gen:matches=true
+// +deepequal-gen:changed-fields=true
+// +deepequal-gen:merge-patch=true
+type Ttest struct {
+	Raw      json.RawMessage `json:"raw,omitempty" deepequal:"json"`
+	Document string          `json:"document,omitempty" deepequal:"json"`
+	Manifest []byte          `json:"manifest,omitempty" deepequal:"yaml"`
+	Version  Version         `json:"version" deepequal:"text"`
+	Level    Level           `json:"level" deepequal:"text"`
+	Limit    *Level          `json:"limit,omitempty" deepequal:"text"`
+	Address  net.IP          `json:"address,omitempty" deepequal:"text"`
+	Stamp    time.Time       `json:"stamp" deepequal:"text"`
+	Plain    []byte          `json:"plain,omitempty"`
+}
@@ -0,0 +1,431 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package encodedfields
+
+import (
+	net "net"
+	time "time"
+
+	deepequal "github.com/wind-river/deepequal-gen/deepequal"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !deepequal.JSONEqual(in.Raw, other.Raw) {
+		return false
+	}
+
+	if !deepequal.JSONEqual([]byte(in.Document), []byte(other.Document)) {
+		return false
+	}
+
+	if !deepequal.YAMLEqual(in.Manifest, other.Manifest) {
+		return false
+	}
+
+	if !deepequal.TextEqual(&in.Version, &other.Version) {
+		return false
+	}
+
+	if !deepequal.TextEqual(&in.Level, &other.Level) {
+		return false
+	}
+
+	if (in.Limit == nil) != (other.Limit == nil) || (in.Limit != nil && !deepequal.TextEqual(in.Limit, other.Limit)) {
+		return false
+	}
+
+	if !deepequal.TextEqual(&in.Address, &other.Address) {
+		return false
+	}
+
+	if !deepequal.TextEqual(&in.Stamp, &other.Stamp) {
+		return false
+	}
+
+	if ((in.Plain != nil) && (other.Plain != nil)) || ((in.Plain == nil) != (other.Plain == nil)) {
+		in, other := &in.Plain, &other.Plain
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepMatches is an autogenerated deepequal function, reporting whether actual
+// matches the receiver.  Zero values in the receiver match any value, slices
+// match as prefixes, or as subsets if they are unordered, and maps as
+// subsets.  in must be non-nil.
+func (in *Ttest) DeepMatches(actual *Ttest) bool {
+	if actual == nil {
+		return false
+	}
+
+	if len(in.Raw) != 0 {
+		if !deepequal.JSONEqual(in.Raw, actual.Raw) {
+			return false
+		}
+	}
+	if len(in.Document) != 0 {
+		if !deepequal.JSONEqual([]byte(in.Document), []byte(actual.Document)) {
+			return false
+		}
+	}
+	if len(in.Manifest) != 0 {
+		if !deepequal.YAMLEqual(in.Manifest, actual.Manifest) {
+			return false
+		}
+	}
+	if !deepequal.TextEqual(new(Version), &in.Version) {
+		if !deepequal.TextEqual(&in.Version, &actual.Version) {
+			return false
+		}
+	}
+	if !deepequal.TextEqual(new(Level), &in.Level) {
+		if !deepequal.TextEqual(&in.Level, &actual.Level) {
+			return false
+		}
+	}
+	if in.Limit != nil {
+		if (in.Limit == nil) != (actual.Limit == nil) || (in.Limit != nil && !deepequal.TextEqual(in.Limit, actual.Limit)) {
+			return false
+		}
+	}
+	if !deepequal.TextEqual(new(net.IP), &in.Address) {
+		if !deepequal.TextEqual(&in.Address, &actual.Address) {
+			return false
+		}
+	}
+	if !deepequal.TextEqual(new(time.Time), &in.Stamp) {
+		if !deepequal.TextEqual(&in.Stamp, &actual.Stamp) {
+			return false
+		}
+	}
+	if len(in.Plain) != 0 {
+		if len(in.Plain) > len(actual.Plain) {
+			return false
+		}
+		for i := range in.Plain {
+			if in.Plain[i] != actual.Plain[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// TtestFieldSet is a set of fields of Ttest.
+type TtestFieldSet uint64
+
+// Fields of Ttest in a TtestFieldSet.
+const (
+	TtestFieldRaw TtestFieldSet = 1 << iota
+	TtestFieldDocument
+	TtestFieldManifest
+	TtestFieldVersion
+	TtestFieldLevel
+	TtestFieldLimit
+	TtestFieldAddress
+	TtestFieldStamp
+	TtestFieldPlain
+)
+
+// Has returns whether s holds all the fields in fields.
+func (s TtestFieldSet) Has(fields TtestFieldSet) bool {
+	return s&fields == fields
+}
+
+// Names returns the names of the fields in s, in declaration order.
+func (s TtestFieldSet) Names() []string {
+	names := []string{}
+	if s&TtestFieldRaw != 0 {
+		names = append(names, "Raw")
+	}
+	if s&TtestFieldDocument != 0 {
+		names = append(names, "Document")
+	}
+	if s&TtestFieldManifest != 0 {
+		names = append(names, "Manifest")
+	}
+	if s&TtestFieldVersion != 0 {
+		names = append(names, "Version")
+	}
+	if s&TtestFieldLevel != 0 {
+		names = append(names, "Level")
+	}
+	if s&TtestFieldLimit != 0 {
+		names = append(names, "Limit")
+	}
+	if s&TtestFieldAddress != 0 {
+		names = append(names, "Address")
+	}
+	if s&TtestFieldStamp != 0 {
+		names = append(names, "Stamp")
+	}
+	if s&TtestFieldPlain != 0 {
+		names = append(names, "Plain")
+	}
+	return names
+}
+
+// ChangedFields is an autogenerated deepequal function, returning the fields of
+// the receiver which differ from those of other when compared like DeepEqual.
+// in and other must be non-nil.
+func (in *Ttest) ChangedFields(other *Ttest) TtestFieldSet {
+	var changed TtestFieldSet
+
+	if !func() bool {
+		if !deepequal.JSONEqual(in.Raw, other.Raw) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldRaw
+	}
+	if !func() bool {
+		if !deepequal.JSONEqual([]byte(in.Document), []byte(other.Document)) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldDocument
+	}
+	if !func() bool {
+		if !deepequal.YAMLEqual(in.Manifest, other.Manifest) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldManifest
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Version, &other.Version) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldVersion
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Level, &other.Level) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldLevel
+	}
+	if !func() bool {
+		if (in.Limit == nil) != (other.Limit == nil) || (in.Limit != nil && !deepequal.TextEqual(in.Limit, other.Limit)) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldLimit
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Address, &other.Address) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldAddress
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Stamp, &other.Stamp) {
+			return false
+		}
+		return true
+	}() {
+		changed |= TtestFieldStamp
+	}
+	if !func() bool {
+		if ((in.Plain != nil) && (other.Plain != nil)) || ((in.Plain == nil) != (other.Plain == nil)) {
+			in, other := &in.Plain, &other.Plain
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for i, inElement := range *in {
+					if inElement != (*other)[i] {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		changed |= TtestFieldPlain
+	}
+
+	return changed
+}
+
+// DeepMergePatch is an autogenerated deepequal function, returning the RFC 7386
+// JSON merge patch turning the JSON encoding of the receiver into that of
+// modified. Fields which DeepEqual considers equal are left out of the patch,
+// and slices are replaced as a whole. in and modified must be non-nil.
+func (in *Ttest) DeepMergePatch(modified *Ttest) ([]byte, error) {
+	other := modified
+	patch := deepequal.MergePatch{}
+
+	if !func() bool {
+		if !deepequal.JSONEqual(in.Raw, other.Raw) {
+			return false
+		}
+		return true
+	}() {
+		if err := patch.Set("raw", other.Raw); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !deepequal.JSONEqual([]byte(in.Document), []byte(other.Document)) {
+			return false
+		}
+		return true
+	}() {
+		if other.Document == "" {
+			patch.Remove("document")
+		} else if err := patch.Set("document", other.Document); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !deepequal.YAMLEqual(in.Manifest, other.Manifest) {
+			return false
+		}
+		return true
+	}() {
+		if len(other.Manifest) == 0 {
+			patch.Remove("manifest")
+		} else if err := patch.Set("manifest", other.Manifest); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Version, &other.Version) {
+			return false
+		}
+		return true
+	}() {
+		if err := patch.Set("version", &other.Version); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Level, &other.Level) {
+			return false
+		}
+		return true
+	}() {
+		if err := patch.Set("level", &other.Level); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if (in.Limit == nil) != (other.Limit == nil) || (in.Limit != nil && !deepequal.TextEqual(in.Limit, other.Limit)) {
+			return false
+		}
+		return true
+	}() {
+		if other.Limit == nil {
+			patch.Remove("limit")
+		} else if err := patch.Set("limit", other.Limit); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Address, &other.Address) {
+			return false
+		}
+		return true
+	}() {
+		if len(other.Address) == 0 {
+			patch.Remove("address")
+		} else if err := patch.Set("address", &other.Address); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !deepequal.TextEqual(&in.Stamp, &other.Stamp) {
+			return false
+		}
+		return true
+	}() {
+		if err := patch.Set("stamp", &other.Stamp); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if ((in.Plain != nil) && (other.Plain != nil)) || ((in.Plain == nil) != (other.Plain == nil)) {
+			in, other := &in.Plain, &other.Plain
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for i, inElement := range *in {
+					if inElement != (*other)[i] {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		if len(other.Plain) == 0 {
+			patch.Remove("plain")
+		} else if err := patch.Set("plain", other.Plain); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch.Marshal()
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Version) DeepEqual(other *Version) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Major != other.Major {
+		return false
+	}
+	if in.Minor != other.Minor {
+		return false
+	}
+	if in.Build != other.Build {
+		return false
+	}
+
+	return true
+}
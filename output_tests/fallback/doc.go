@@ -0,0 +1,27 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.  The types of its fields are outside the bounding
+// dirs and have no DeepEqual method, so they are compared with == when
+// possible and with reflect.DeepEqual otherwise.
+package fallback
+
+import (
+	"image"
+	"net"
+	"time"
+)
+
+type Ttest struct {
+	Point    image.Point
+	PointPtr *image.Point
+	Points   []image.Point
+	Time     time.Time
+	TimePtr  *time.Time
+	IPs      []net.IP
+	Matrix   [][]int
+}
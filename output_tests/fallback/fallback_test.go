@@ -0,0 +1,84 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package fallback
+
+import (
+	"image"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFallback(t *testing.T) {
+	now := time.Unix(1, 0)
+	newTtest := func() Ttest {
+		return Ttest{
+			Point:    image.Pt(1, 2),
+			PointPtr: &image.Point{X: 3},
+			Points:   []image.Point{image.Pt(4, 5)},
+			Time:     now,
+			TimePtr:  &now,
+			IPs:      []net.IP{net.ParseIP("10.0.0.1")},
+			Matrix:   [][]int{{1, 2}, {3}},
+		}
+	}
+	x := newTtest()
+
+	testCases := []struct {
+		name   string
+		mutate func(y *Ttest)
+		equal  bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(y *Ttest) {},
+			equal:  true,
+		},
+		{
+			name:   "point",
+			mutate: func(y *Ttest) { y.Point.X = 0 },
+			equal:  false,
+		},
+		{
+			name:   "point pointer",
+			mutate: func(y *Ttest) { y.PointPtr = &image.Point{X: 4} },
+			equal:  false,
+		},
+		{
+			name:   "points",
+			mutate: func(y *Ttest) { y.Points[0].Y = 0 },
+			equal:  false,
+		},
+		{
+			name:   "time",
+			mutate: func(y *Ttest) { y.Time = now.Add(time.Second) },
+			equal:  false,
+		},
+		{
+			name:   "time pointer",
+			mutate: func(y *Ttest) { later := now.Add(time.Second); y.TimePtr = &later },
+			equal:  false,
+		},
+		{
+			name:   "ips",
+			mutate: func(y *Ttest) { y.IPs[0] = net.ParseIP("10.0.0.2") },
+			equal:  false,
+		},
+		{
+			name:   "matrix",
+			mutate: func(y *Ttest) { y.Matrix[1] = []int{4} },
+			equal:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		y := newTtest()
+		tc.mutate(&y)
+		if got := x.DeepEqual(&y); got != tc.equal {
+			t.Errorf("%s: expected DeepEqual %t, got %t", tc.name, tc.equal, got)
+		}
+	}
+}
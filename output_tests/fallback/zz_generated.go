@@ -0,0 +1,97 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package fallback
+
+import (
+	reflect "reflect"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Point != other.Point {
+		return false
+	}
+
+	if (in.PointPtr == nil) != (other.PointPtr == nil) {
+		return false
+	} else if in.PointPtr != nil {
+		if *in.PointPtr != *other.PointPtr {
+			return false
+		}
+	}
+
+	if ((in.Points != nil) && (other.Points != nil)) || ((in.Points == nil) != (other.Points == nil)) {
+		in, other := &in.Points, &other.Points
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(in.Time, other.Time) {
+		return false
+	}
+
+	if (in.TimePtr == nil) != (other.TimePtr == nil) {
+		return false
+	} else if in.TimePtr != nil {
+		if !reflect.DeepEqual(in.TimePtr, other.TimePtr) {
+			return false
+		}
+	}
+
+	if ((in.IPs != nil) && (other.IPs != nil)) || ((in.IPs == nil) != (other.IPs == nil)) {
+		in, other := &in.IPs, &other.IPs
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !reflect.DeepEqual(inElement, (*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Matrix != nil) && (other.Matrix != nil)) || ((in.Matrix == nil) != (other.Matrix == nil)) {
+		in, other := &in.Matrix, &other.Matrix
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !reflect.DeepEqual(inElement, (*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package fieldmask
+
+import (
+	"testing"
+
+	"github.com/wind-river/deepequal-gen/deepequal"
+)
+
+func newValue() Ttest {
+	replicas := int32(3)
+	return Ttest{
+		Meta: Meta{Name: "web", Labels: map[string]string{"app": "web"}},
+		Spec: Spec{
+			Replicas: &replicas,
+			Ports:    []Port{{Name: "http", Number: 80}, {Name: "https", Number: 443}},
+			Selector: map[string]Port{"main": {Name: "http", Number: 80}},
+		},
+		Status:   &Status{Ready: true},
+		Revision: 1,
+		Internal: "a",
+	}
+}
+
+func TestDeepEqualMasked(t *testing.T) {
+	replicas := int32(5)
+	changed := newValue()
+	changed.Spec.Replicas = &replicas
+	changed.Spec.Ports[1].Number = 8443
+	changed.Spec.Selector["main"] = Port{Name: "http", Number: 8080}
+	changed.Labels = map[string]string{"app": "api"}
+	changed.Revision = 2
+	changed.Internal = "b"
+	changed.private = "b"
+
+	cases := []struct {
+		name  string
+		mask  deepequal.FieldMask
+		equal bool
+	}{
+		{"all fields", deepequal.FieldMask{}, false},
+		{"unchanged field", deepequal.Include(deepequal.JSONNames, TtestPathStatus), true},
+		{"changed field", deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathReplicas)), false},
+		{"unchanged nested field", deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathPaused)), true},
+		{"unchanged slice element field", deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathPorts, PortPathName)), true},
+		{"changed slice element field", deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathPorts, PortPathNumber)), false},
+		{"unchanged map value field", deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathSelector, PortPathName)), true},
+		{"changed map value field", deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathSelector, PortPathNumber)), false},
+		{"embedded field", deepequal.Include(deepequal.JSONNames, MetaPathName), true},
+		{"changed embedded field", deepequal.Include(deepequal.JSONNames, MetaPathLabels), false},
+		{"embedded field by go name", deepequal.Include(deepequal.GoNames, "Meta.Name"), true},
+		{"field without json name", deepequal.Include(deepequal.JSONNames, "Internal"), true},
+		{"field by go name", deepequal.Include(deepequal.GoNames, "Internal"), false},
+		{"unexported field", deepequal.Include(deepequal.GoNames, "private"), false},
+		{"excluded changes", deepequal.Exclude(deepequal.GoNames, "Meta.Labels", "Spec.Replicas", "Spec.Ports.Number", "Spec.Selector", "Revision", "Internal", "private"), true},
+		{"excluded changes but one", deepequal.Exclude(deepequal.GoNames, "Meta.Labels", "Spec.Replicas", "Spec.Ports.Number", "Spec.Selector", "Internal", "private"), false},
+		{"nothing", deepequal.Include(deepequal.JSONNames), true},
+	}
+	for _, c := range cases {
+		x, y := newValue(), changed
+		if equal := x.DeepEqualMasked(&y, c.mask); equal != c.equal {
+			t.Errorf("%s: expected DeepEqualMasked to return %t, got %t", c.name, c.equal, equal)
+		}
+	}
+}
+
+func TestDeepEqualMaskedStructure(t *testing.T) {
+	x, y := newValue(), newValue()
+	y.Status = nil
+	mask := deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathStatus, StatusPathReady))
+	if x.DeepEqualMasked(&y, mask) {
+		t.Errorf("expected a nil pointer on a selected path to differ")
+	}
+	y = newValue()
+	y.Spec.Ports = y.Spec.Ports[:1]
+	mask = deepequal.Include(deepequal.JSONNames, deepequal.Path(TtestPathSpec, SpecPathPorts, PortPathName))
+	if x.DeepEqualMasked(&y, mask) {
+		t.Errorf("expected slices of different lengths on a selected path to differ")
+	}
+}
@@ -0,0 +1,38 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package fieldmask
+
+type Meta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type Port struct {
+	Name   string `json:"name"`
+	Number int32  `json:"number"`
+}
+
+type Spec struct {
+	Replicas *int32 `json:"replicas,omitempty"`
+	Paused   bool   `json:"paused"`
+	// +deepequal-gen:unordered-array=true
+	Ports    []Port          `json:"ports"`
+	Selector map[string]Port `json:"selector"`
+}
+
+// +deepequal-gen:field-mask=true
+type Ttest struct {
+	Meta     `json:",inline"`
+	Spec     Spec    `json:"spec"`
+	Status   *Status `json:"status,omitempty"`
+	Revision int64
+	Internal string `json:"-"`
+	private  string
+}
+
+type Status struct {
+	Ready bool `json:"ready"`
+}
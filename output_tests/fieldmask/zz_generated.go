@@ -0,0 +1,453 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package fieldmask
+
+import (
+	deepequal "github.com/wind-river/deepequal-gen/deepequal"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Meta) DeepEqual(other *Meta) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// Names of the fields of Meta in the paths of a deepequal.FieldMask.
+const (
+	MetaPathName   = "name"
+	MetaPathLabels = "labels"
+)
+
+// DeepEqualMasked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual, but only on the fields selected by
+// mask. in must be non-nil.
+func (in *Meta) DeepEqualMasked(other *Meta, mask deepequal.FieldMask) bool {
+	if mask.All() {
+		return in.DeepEqual(other)
+	}
+	if !mask.Selected() {
+		return true
+	}
+
+	if other == nil {
+		return false
+	}
+
+	if mask := mask.Field("Name", "name"); mask.Selected() {
+		if in.Name != other.Name {
+			return false
+		}
+	}
+
+	if mask := mask.Field("Labels", "labels"); mask.Selected() {
+		if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+			in, other := &in.Labels, &other.Labels
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for key, inValue := range *in {
+					if otherValue, present := (*other)[key]; !present {
+						return false
+					} else {
+						if inValue != otherValue {
+							return false
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Port) DeepEqual(other *Port) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Number != other.Number {
+		return false
+	}
+
+	return true
+}
+
+// Names of the fields of Port in the paths of a deepequal.FieldMask.
+const (
+	PortPathName   = "name"
+	PortPathNumber = "number"
+)
+
+// DeepEqualMasked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual, but only on the fields selected by
+// mask. in must be non-nil.
+func (in *Port) DeepEqualMasked(other *Port, mask deepequal.FieldMask) bool {
+	if mask.All() {
+		return in.DeepEqual(other)
+	}
+	if !mask.Selected() {
+		return true
+	}
+
+	if other == nil {
+		return false
+	}
+
+	if mask := mask.Field("Name", "name"); mask.Selected() {
+		if in.Name != other.Name {
+			return false
+		}
+	}
+
+	if mask := mask.Field("Number", "number"); mask.Selected() {
+		if in.Number != other.Number {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Spec) DeepEqual(other *Spec) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Replicas == nil) != (other.Replicas == nil) {
+		return false
+	} else if in.Replicas != nil {
+		if *in.Replicas != *other.Replicas {
+			return false
+		}
+	}
+
+	if in.Paused != other.Paused {
+		return false
+	}
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement.DeepEqual(&otherElement) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Selector != nil) && (other.Selector != nil)) || ((in.Selector == nil) != (other.Selector == nil)) {
+		in, other := &in.Selector, &other.Selector
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// Names of the fields of Spec in the paths of a deepequal.FieldMask.
+const (
+	SpecPathReplicas = "replicas"
+	SpecPathPaused   = "paused"
+	SpecPathPorts    = "ports"
+	SpecPathSelector = "selector"
+)
+
+// DeepEqualMasked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual, but only on the fields selected by
+// mask. in must be non-nil.
+func (in *Spec) DeepEqualMasked(other *Spec, mask deepequal.FieldMask) bool {
+	if mask.All() {
+		return in.DeepEqual(other)
+	}
+	if !mask.Selected() {
+		return true
+	}
+
+	if other == nil {
+		return false
+	}
+
+	if mask := mask.Field("Replicas", "replicas"); mask.Selected() {
+		if (in.Replicas == nil) != (other.Replicas == nil) {
+			return false
+		} else if in.Replicas != nil {
+			if *in.Replicas != *other.Replicas {
+				return false
+			}
+		}
+	}
+
+	if mask := mask.Field("Paused", "paused"); mask.Selected() {
+		if in.Paused != other.Paused {
+			return false
+		}
+	}
+
+	if mask := mask.Field("Ports", "ports"); mask.Selected() {
+		if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+			in, other := &in.Ports, &other.Ports
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for _, inElement := range *in {
+					found := false
+					for _, otherElement := range *other {
+						if inElement.DeepEqualMasked(&otherElement, mask) {
+							found = true
+							break
+						}
+					}
+					if !found {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if mask := mask.Field("Selector", "selector"); mask.Selected() {
+		if ((in.Selector != nil) && (other.Selector != nil)) || ((in.Selector == nil) != (other.Selector == nil)) {
+			in, other := &in.Selector, &other.Selector
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for key, inValue := range *in {
+					if otherValue, present := (*other)[key]; !present {
+						return false
+					} else {
+						if !inValue.DeepEqualMasked(&otherValue, mask) {
+							return false
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Status) DeepEqual(other *Status) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Ready != other.Ready {
+		return false
+	}
+
+	return true
+}
+
+// Names of the fields of Status in the paths of a deepequal.FieldMask.
+const (
+	StatusPathReady = "ready"
+)
+
+// DeepEqualMasked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual, but only on the fields selected by
+// mask. in must be non-nil.
+func (in *Status) DeepEqualMasked(other *Status, mask deepequal.FieldMask) bool {
+	if mask.All() {
+		return in.DeepEqual(other)
+	}
+	if !mask.Selected() {
+		return true
+	}
+
+	if other == nil {
+		return false
+	}
+
+	if mask := mask.Field("Ready", "ready"); mask.Selected() {
+		if in.Ready != other.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.Meta.DeepEqual(&other.Meta) {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if (in.Status == nil) != (other.Status == nil) {
+		return false
+	} else if in.Status != nil {
+		if !in.Status.DeepEqual(other.Status) {
+			return false
+		}
+	}
+
+	if in.Revision != other.Revision {
+		return false
+	}
+	if in.Internal != other.Internal {
+		return false
+	}
+	if in.private != other.private {
+		return false
+	}
+
+	return true
+}
+
+// Names of the fields of Ttest in the paths of a deepequal.FieldMask.
+const (
+	TtestPathSpec     = "spec"
+	TtestPathStatus   = "status"
+	TtestPathRevision = "Revision"
+)
+
+// DeepEqualMasked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual, but only on the fields selected by
+// mask. in must be non-nil.
+func (in *Ttest) DeepEqualMasked(other *Ttest, mask deepequal.FieldMask) bool {
+	if mask.All() {
+		return in.DeepEqual(other)
+	}
+	if !mask.Selected() {
+		return true
+	}
+
+	if other == nil {
+		return false
+	}
+
+	if mask := mask.Embedded("Meta"); mask.Selected() {
+		if !in.Meta.DeepEqualMasked(&other.Meta, mask) {
+			return false
+		}
+	}
+
+	if mask := mask.Field("Spec", "spec"); mask.Selected() {
+		if !in.Spec.DeepEqualMasked(&other.Spec, mask) {
+			return false
+		}
+	}
+
+	if mask := mask.Field("Status", "status"); mask.Selected() {
+		if (in.Status == nil) != (other.Status == nil) {
+			return false
+		} else if in.Status != nil {
+			if !in.Status.DeepEqualMasked(other.Status, mask) {
+				return false
+			}
+		}
+	}
+
+	if mask := mask.Field("Revision", "Revision"); mask.Selected() {
+		if in.Revision != other.Revision {
+			return false
+		}
+	}
+
+	if mask := mask.Field("Internal", ""); mask.Selected() {
+		if in.Internal != other.Internal {
+			return false
+		}
+	}
+
+	if mask := mask.Field("private", ""); mask.Selected() {
+		if in.private != other.private {
+			return false
+		}
+	}
+
+	return true
+}
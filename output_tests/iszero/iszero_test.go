@@ -0,0 +1,105 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package iszero
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDeepIsZero(t *testing.T) {
+	name := ""
+	cases := []struct {
+		name string
+		in   Ttest
+		zero bool
+	}{
+		{name: "zero", in: Ttest{}, zero: true},
+		{name: "empty values", in: Ttest{Slice: []string{}, Labels: Labels{}, Spec: Spec{Ports: Ports{}}, Raw: json.RawMessage{}}, zero: true},
+		{name: "nil equals empty", in: Ttest{Lenient: map[string]interface{}{}}, zero: true},
+		{name: "bool", in: Ttest{Flag: true}},
+		{name: "int", in: Ttest{Count: 1}},
+		{name: "float", in: Ttest{Ratio: 0.5}},
+		{name: "string", in: Ttest{Name: "a"}},
+		{name: "pointer to zero", in: Ttest{Pointer: &name}},
+		{name: "slice", in: Ttest{Slice: []string{""}}},
+		{name: "map", in: Ttest{Labels: Labels{"": ""}}},
+		{name: "comparable struct", in: Ttest{Point: Point{Y: 1}}},
+		{name: "nested struct", in: Ttest{Spec: Spec{Ports: Ports{0}}}},
+		{name: "interface", in: Ttest{Value: 0}},
+		{name: "empty unstructured", in: Ttest{Value: map[string]interface{}{}}},
+		{name: "encoded", in: Ttest{Raw: json.RawMessage(`{}`)}},
+	}
+	for i := range cases {
+		c := &cases[i]
+		if got := c.in.DeepIsZero(); got != c.zero {
+			t.Errorf("%s: expected %v, got %v", c.name, c.zero, got)
+		}
+		if got := c.in.DeepEqual(&Ttest{}); got != c.zero {
+			t.Errorf("%s: expected DeepEqual with the zero value to return %v, got %v", c.name, c.zero, got)
+		}
+	}
+}
+
+func TestDeepIsZeroAtomic(t *testing.T) {
+	var counters Counters
+	if !counters.DeepIsZero() {
+		t.Errorf("expected a zero counter to be zero")
+	}
+	counters.Hits.Store(1)
+	if counters.DeepIsZero() {
+		t.Errorf("expected a stored counter not to be zero")
+	}
+}
+
+func TestDeepIsZeroIgnoredNils(t *testing.T) {
+	value := 0
+	cases := []struct {
+		in   Optional
+		zero bool
+	}{
+		{Optional{}, true},
+		{Optional{Pointer: &value}, false},
+		{Optional{Slice: []string{}}, false},
+	}
+	for i, c := range cases {
+		if got := c.in.DeepIsZero(); got != c.zero {
+			t.Errorf("case[%d]: expected %v, got %v", i, c.zero, got)
+		}
+		if !c.in.DeepEqual(&Optional{}) {
+			t.Errorf("case[%d]: expected nil fields to be ignored by DeepEqual", i)
+		}
+	}
+}
+
+func TestDeepIsZeroJSONSemantics(t *testing.T) {
+	cases := []struct {
+		in   Document
+		zero bool
+	}{
+		{Document{}, true},
+		{Document{Tags: map[string]string{}}, true},
+		{Document{Items: []string{}}, false},
+	}
+	for i, c := range cases {
+		if got := c.in.DeepIsZero(); got != c.zero {
+			t.Errorf("case[%d]: expected %v, got %v", i, c.zero, got)
+		}
+		if got := c.in.DeepEqual(&Document{}); got != c.zero {
+			t.Errorf("case[%d]: expected DeepEqual with the zero value to return %v, got %v", i, c.zero, got)
+		}
+	}
+}
+
+func TestDeepIsZeroAllocations(t *testing.T) {
+	in := Ttest{Spec: Spec{Name: "a"}}
+	if allocs := testing.AllocsPerRun(100, func() { in.DeepIsZero() }); allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+	if (&Ports{}).DeepIsZero() != true || (&Ports{1}).DeepIsZero() != false {
+		t.Errorf("unexpected DeepIsZero result for a slice type")
+	}
+}
@@ -0,0 +1,61 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package iszero
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+type Labels map[string]string
+
+// +deepequal-gen:is-zero=true
+type Ports []int
+
+type Point struct {
+	X, Y int
+}
+
+type Spec struct {
+	Name  string
+	Ports Ports
+}
+
+// +deepequal-gen:is-zero=true
+type Ttest struct {
+	Flag    bool
+	Count   int
+	Ratio   float64
+	Name    string
+	Pointer *string
+	Slice   []string
+	Labels  Labels
+	Point   Point
+	Spec    Spec
+	Value   interface{}
+	// +deepequal-gen:nil-equals-empty=true
+	Lenient map[string]interface{}
+	Raw     json.RawMessage `deepequal:"json"`
+}
+
+// +deepequal-gen:is-zero=true
+// +deepequal-gen:ignore-nil-fields=either
+type Optional struct {
+	Pointer *int
+	Slice   []string
+}
+
+// +deepequal-gen:is-zero=true
+// +deepequal-gen:semantics=json
+type Document struct {
+	Items []string          `json:"items"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
+
+// +deepequal-gen:is-zero=true
+type Counters struct {
+	Hits atomic.Int64
+}
@@ -0,0 +1,398 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package iszero
+
+import (
+	reflect "reflect"
+
+	deepequal "github.com/wind-river/deepequal-gen/deepequal"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Counters) DeepEqual(other *Counters) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Hits.Load() != other.Hits.Load() {
+		return false
+	}
+
+	return true
+}
+
+// DeepIsZero is an autogenerated deepequal function, reporting whether the
+// receiver is equal to the zero value of its type by DeepEqual.  Fields
+// which DeepEqual skips when they are nil are only zero when nil.  in must be
+// non-nil.
+func (in *Counters) DeepIsZero() bool {
+	if in.Hits.Load() != 0 {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Document) DeepEqual(other *Document) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Items == nil) != (other.Items == nil) {
+		return false
+	}
+	if ((in.Items != nil) && (other.Items != nil)) || ((in.Items == nil) != (other.Items == nil)) {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepIsZero is an autogenerated deepequal function, reporting whether the
+// receiver is equal to the zero value of its type by DeepEqual.  Fields
+// which DeepEqual skips when they are nil are only zero when nil.  in must be
+// non-nil.
+func (in *Document) DeepIsZero() bool {
+	if in.Items != nil {
+		return false
+	}
+	if len(in.Tags) != 0 {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Labels) DeepEqual(other *Labels) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for key, inValue := range *in {
+			if otherValue, present := (*other)[key]; !present {
+				return false
+			} else {
+				if inValue != otherValue {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Optional) DeepEqual(other *Optional) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Pointer != nil && other.Pointer != nil {
+		if (in.Pointer == nil) != (other.Pointer == nil) {
+			return false
+		} else if in.Pointer != nil {
+			if *in.Pointer != *other.Pointer {
+				return false
+			}
+		}
+	}
+
+	if in.Slice != nil && other.Slice != nil {
+		in, other := &in.Slice, &other.Slice
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepIsZero is an autogenerated deepequal function, reporting whether the
+// receiver is equal to the zero value of its type by DeepEqual.  Fields
+// which DeepEqual skips when they are nil are only zero when nil.  in must be
+// non-nil.
+func (in *Optional) DeepIsZero() bool {
+	if in.Pointer != nil {
+		return false
+	}
+	if in.Slice != nil {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Point) DeepEqual(other *Point) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.X != other.X {
+		return false
+	}
+	if in.Y != other.Y {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ports) DeepEqual(other *Ports) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for i, inElement := range *in {
+			if inElement != (*other)[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepIsZero is an autogenerated deepequal function, reporting whether the
+// receiver is equal to the zero value of its type by DeepEqual.  Fields
+// which DeepEqual skips when they are nil are only zero when nil.  in must be
+// non-nil.
+func (in *Ports) DeepIsZero() bool {
+	return len(*in) == 0
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Spec) DeepEqual(other *Spec) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepIsZero is an autogenerated deepequal function, reporting whether the
+// receiver is equal to the zero value of its type by DeepEqual.  Fields
+// which DeepEqual skips when they are nil are only zero when nil.  in must be
+// non-nil.
+func (in *Spec) DeepIsZero() bool {
+	if in.Name != "" {
+		return false
+	}
+	if !in.Ports.DeepIsZero() {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Flag != other.Flag {
+		return false
+	}
+	if in.Count != other.Count {
+		return false
+	}
+	if in.Ratio != other.Ratio {
+		return false
+	}
+	if in.Name != other.Name {
+		return false
+	}
+	if (in.Pointer == nil) != (other.Pointer == nil) {
+		return false
+	} else if in.Pointer != nil {
+		if *in.Pointer != *other.Pointer {
+			return false
+		}
+	}
+
+	if ((in.Slice != nil) && (other.Slice != nil)) || ((in.Slice == nil) != (other.Slice == nil)) {
+		in, other := &in.Slice, &other.Slice
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	if in.Point != other.Point {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if !reflect.DeepEqual(in.Value, other.Value) {
+		return false
+	}
+
+	if ((in.Lenient != nil) && (other.Lenient != nil)) || ((in.Lenient == nil) != (other.Lenient == nil)) {
+		in, other := &in.Lenient, &other.Lenient
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !deepequal.UnstructuredEqual(inValue, otherValue, deepequal.NilEqualsEmpty) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if !deepequal.JSONEqual(in.Raw, other.Raw) {
+		return false
+	}
+
+	return true
+}
+
+// DeepIsZero is an autogenerated deepequal function, reporting whether the
+// receiver is equal to the zero value of its type by DeepEqual.  Fields
+// which DeepEqual skips when they are nil are only zero when nil.  in must be
+// non-nil.
+func (in *Ttest) DeepIsZero() bool {
+	if in.Flag {
+		return false
+	}
+	if in.Count != 0 {
+		return false
+	}
+	if in.Ratio != 0 {
+		return false
+	}
+	if in.Name != "" {
+		return false
+	}
+	if in.Pointer != nil {
+		return false
+	}
+	if len(in.Slice) != 0 {
+		return false
+	}
+	if len(in.Labels) != 0 {
+		return false
+	}
+	if in.Point != (Point{}) {
+		return false
+	}
+	if !in.Spec.DeepIsZero() {
+		return false
+	}
+	if in.Value != nil {
+		return false
+	}
+	if len(in.Lenient) != 0 {
+		return false
+	}
+	if len(in.Raw) != 0 {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,65 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package jsonsemantics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONSemantics(t *testing.T) {
+	note, other := "a", "b"
+	values := []Ttest{
+		{},
+		{Name: "a"},
+		{inner: inner{Count: 1}},
+		{Labels: map[string]string{}},
+		{Labels: map[string]string{"a": "b"}},
+		{Tags: []string{}},
+		{Tags: []string{"a"}},
+		{Items: []Item{}},
+		{Items: []Item{{Name: "a"}}},
+		{Data: []byte{}},
+		{Data: []byte("a")},
+		{Note: &note},
+		{Note: &other},
+		{Internal: "a"},
+		{Dash: "a"},
+		{cache: map[string]int{"a": 1}},
+	}
+	for i := range values {
+		for j := range values {
+			a, b := values[i], values[j]
+			ja, err := json.Marshal(&a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			jb, err := json.Marshal(&b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := bytes.Equal(ja, jb); a.DeepEqual(&b) != expected {
+				t.Errorf("%s == %s: expected %v, got %v", ja, jb, expected, !expected)
+			}
+			if c := a.DeepCompare(&b); (c == 0) != a.DeepEqual(&b) || c != -b.DeepCompare(&a) {
+				t.Errorf("%s <=> %s: inconsistent order %d", ja, jb, c)
+			}
+		}
+	}
+}
+
+func TestGoSemantics(t *testing.T) {
+	a := Plain{Internal: "a"}
+	b := Plain{Tags: []string{}, Internal: "b"}
+	if a.DeepEqual(&b) {
+		t.Errorf("expected fields left out of JSON to be compared")
+	}
+	b.Internal = "a"
+	if !a.DeepEqual(&b) {
+		t.Errorf("expected nil and empty slices to be equal")
+	}
+}
@@ -0,0 +1,36 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package jsonsemantics
+
+type Item struct {
+	Name string `json:"name"`
+}
+
+type inner struct {
+	Count int `json:"count"`
+}
+
+// +deepequal-gen:semantics=json
+// +deepequal-gen:compare=true
+type Ttest struct {
+	inner
+	Name     string            `json:"name"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Tags     []string          `json:"tags"`
+	Items    []Item            `json:"items,omitempty"`
+	Data     []byte            `json:"data"`
+	Note     *string           `json:"note,omitempty"`
+	Internal string            `json:"-"`
+	Dash     string            `json:"-,"`
+	cache    map[string]int
+}
+
+// +deepequal-gen:semantics=go
+type Plain struct {
+	Name     string   `json:"name"`
+	Tags     []string `json:"tags"`
+	Internal string   `json:"-"`
+}
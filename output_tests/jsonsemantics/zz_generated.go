@@ -0,0 +1,376 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package jsonsemantics
+
+import (
+	sort "sort"
+)
+
+// deepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *inner) deepEqual(other *inner) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Count != other.Count {
+		return false
+	}
+
+	return true
+}
+
+// deepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when deepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *inner) deepCompare(other *inner) int {
+	if other == nil {
+		return 1
+	}
+
+	if in.Count != other.Count {
+		if in.Count < other.Count {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Item) DeepEqual(other *Item) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+
+	return true
+}
+
+// DeepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when DeepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *Item) DeepCompare(other *Item) int {
+	if other == nil {
+		return 1
+	}
+
+	if in.Name != other.Name {
+		if in.Name < other.Name {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Plain) DeepEqual(other *Plain) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if in.Internal != other.Internal {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.inner != other.inner {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if (in.Tags == nil) != (other.Tags == nil) {
+		return false
+	}
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Items != nil) && (other.Items != nil)) || ((in.Items == nil) != (other.Items == nil)) {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if (in.Data == nil) != (other.Data == nil) {
+		return false
+	}
+	if ((in.Data != nil) && (other.Data != nil)) || ((in.Data == nil) != (other.Data == nil)) {
+		in, other := &in.Data, &other.Data
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if (in.Note == nil) != (other.Note == nil) {
+		return false
+	} else if in.Note != nil {
+		if *in.Note != *other.Note {
+			return false
+		}
+	}
+
+	if in.Dash != other.Dash {
+		return false
+	}
+
+	return true
+}
+
+// DeepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when DeepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *Ttest) DeepCompare(other *Ttest) int {
+	if other == nil {
+		return 1
+	}
+
+	if c := in.inner.deepCompare(&other.inner); c != 0 {
+		return c
+	}
+	if in.Name != other.Name {
+		if in.Name < other.Name {
+			return -1
+		}
+		return 1
+	}
+	{
+		compare := func(in, other *string) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		if len(in.Labels) != len(other.Labels) {
+			if len(in.Labels) < len(other.Labels) {
+				return -1
+			}
+			return 1
+		}
+		keys := make([]string, 0, len(in.Labels))
+		for key := range in.Labels {
+			keys = append(keys, key)
+		}
+		for key := range other.Labels {
+			if _, found := in.Labels[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			inValue, inFound := in.Labels[key]
+			otherValue, otherFound := other.Labels[key]
+			if inFound != otherFound {
+				if !inFound {
+					return -1
+				}
+				return 1
+			}
+			if c := compare(&inValue, &otherValue); c != 0 {
+				return c
+			}
+		}
+	}
+	if (in.Tags == nil) != (other.Tags == nil) {
+		if in.Tags == nil {
+			return -1
+		}
+		return 1
+	}
+	{
+		compare := func(in, other *string) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		for i := 0; i < len(in.Tags) && i < len(other.Tags); i++ {
+			if c := compare(&in.Tags[i], &other.Tags[i]); c != 0 {
+				return c
+			}
+		}
+		if len(in.Tags) != len(other.Tags) {
+			if len(in.Tags) < len(other.Tags) {
+				return -1
+			}
+			return 1
+		}
+	}
+	{
+		compare := func(in, other *Item) int {
+			if c := (*in).DeepCompare(&(*other)); c != 0 {
+				return c
+			}
+			return 0
+		}
+		for i := 0; i < len(in.Items) && i < len(other.Items); i++ {
+			if c := compare(&in.Items[i], &other.Items[i]); c != 0 {
+				return c
+			}
+		}
+		if len(in.Items) != len(other.Items) {
+			if len(in.Items) < len(other.Items) {
+				return -1
+			}
+			return 1
+		}
+	}
+	if (in.Data == nil) != (other.Data == nil) {
+		if in.Data == nil {
+			return -1
+		}
+		return 1
+	}
+	{
+		compare := func(in, other *byte) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		for i := 0; i < len(in.Data) && i < len(other.Data); i++ {
+			if c := compare(&in.Data[i], &other.Data[i]); c != 0 {
+				return c
+			}
+		}
+		if len(in.Data) != len(other.Data) {
+			if len(in.Data) < len(other.Data) {
+				return -1
+			}
+			return 1
+		}
+	}
+	if (in.Note == nil) != (other.Note == nil) {
+		if in.Note == nil {
+			return -1
+		}
+		return 1
+	}
+	if in.Note != nil {
+		if (*in.Note) != (*other.Note) {
+			if (*in.Note) < (*other.Note) {
+				return -1
+			}
+			return 1
+		}
+	}
+	if in.Dash != other.Dash {
+		if in.Dash < other.Dash {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,10 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.  Its types are guarded by a mutex, and generate a
+// DeepEqualLocked method holding it during the comparison.
+package locked
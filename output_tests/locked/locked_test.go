@@ -0,0 +1,117 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package locked
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocked(t *testing.T) {
+	x := &Ttest{Items: []string{"a"}}
+	y := &Ttest{Items: []string{"a"}}
+	if !x.DeepEqualLocked(y) || !x.DeepEqualLocked(x) {
+		t.Errorf("expected equal values")
+	}
+	y.Items = []string{"b"}
+	if x.DeepEqualLocked(y) {
+		t.Errorf("expected different values")
+	}
+	if x.DeepEqualLocked(nil) {
+		t.Errorf("expected nil to differ")
+	}
+
+	a := &Embedded{Count: 1}
+	b := &Embedded{Count: 1}
+	if !a.DeepEqualLocked(b) || !a.DeepEqualLocked(a) {
+		t.Errorf("expected equal values")
+	}
+}
+
+func TestLockedShared(t *testing.T) {
+	mu := &sync.Mutex{}
+	a := &SharedMutex{mu: mu, Name: "a"}
+	b := &SharedMutex{mu: mu, Name: "a"}
+	if !a.DeepEqualLocked(b) || !b.DeepEqualLocked(a) {
+		t.Errorf("expected equal values")
+	}
+	b.Name = "b"
+	if a.DeepEqualLocked(b) {
+		t.Errorf("expected different values")
+	}
+
+	// A reader lock taken twice deadlocks when a writer waits in between.
+	rw := &sync.RWMutex{}
+	x := &Shared{mu: rw, Name: "a"}
+	y := &Shared{mu: rw, Name: "a"}
+	stop := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				rw.Lock()
+				rw.Unlock()
+			}
+		}
+	}()
+	defer close(stop)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100000; i++ {
+			if !x.DeepEqualLocked(y) {
+				t.Errorf("expected equal values")
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("DeepEqualLocked deadlocked on a shared lock")
+	}
+}
+
+func TestLockedConcurrent(t *testing.T) {
+	x := &Ttest{Items: []string{"a"}}
+	y := &Ttest{Items: []string{"a"}}
+
+	// Compare in both directions while the values are being written.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				x.DeepEqualLocked(y)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				y.DeepEqualLocked(x)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				for _, v := range []*Ttest{x, y} {
+					v.mu.Lock()
+					v.Items = append(v.Items[:0], "a")
+					v.mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !x.DeepEqualLocked(y) {
+		t.Errorf("expected equal values")
+	}
+}
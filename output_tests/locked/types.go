@@ -0,0 +1,34 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package locked
+
+import (
+	"sync"
+)
+
+// +deepequal-gen:lock=mu
+type Ttest struct {
+	mu    sync.RWMutex
+	Items []string
+}
+
+// +deepequal-gen:lock=Mutex
+type Embedded struct {
+	sync.Mutex
+	Count int
+}
+
+// +deepequal-gen:lock=mu
+type Shared struct {
+	mu   *sync.RWMutex
+	Name string
+}
+
+// +deepequal-gen:lock=mu
+type SharedMutex struct {
+	mu   *sync.Mutex
+	Name string
+}
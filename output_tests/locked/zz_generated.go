@@ -0,0 +1,189 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package locked
+
+import (
+	unsafe "unsafe"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Embedded) DeepEqual(other *Embedded) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Count != other.Count {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqualLocked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual while holding the lock in the
+// Mutex field of both. The locks are taken in address order, and a lock
+// shared by both only once, so that concurrent comparisons in both
+// directions cannot deadlock. in must be non-nil.
+func (in *Embedded) DeepEqualLocked(other *Embedded) bool {
+	if other == nil {
+		return false
+	}
+	first, second := &in.Mutex, &other.Mutex
+	if first == second {
+		first.Lock()
+		defer first.Unlock()
+		return in.DeepEqual(other)
+	}
+
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	return in.DeepEqual(other)
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Shared) DeepEqual(other *Shared) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqualLocked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual while holding the lock in the
+// mu field of both. The locks are taken in address order, and a lock
+// shared by both only once, so that concurrent comparisons in both
+// directions cannot deadlock. in must be non-nil.
+func (in *Shared) DeepEqualLocked(other *Shared) bool {
+	if other == nil {
+		return false
+	}
+	first, second := in.mu, other.mu
+	if first == second {
+		first.RLock()
+		defer first.RUnlock()
+		return in.DeepEqual(other)
+	}
+
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.RLock()
+	defer first.RUnlock()
+	second.RLock()
+	defer second.RUnlock()
+
+	return in.DeepEqual(other)
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *SharedMutex) DeepEqual(other *SharedMutex) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqualLocked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual while holding the lock in the
+// mu field of both. The locks are taken in address order, and a lock
+// shared by both only once, so that concurrent comparisons in both
+// directions cannot deadlock. in must be non-nil.
+func (in *SharedMutex) DeepEqualLocked(other *SharedMutex) bool {
+	if other == nil {
+		return false
+	}
+	first, second := in.mu, other.mu
+	if first == second {
+		first.Lock()
+		defer first.Unlock()
+		return in.DeepEqual(other)
+	}
+
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	return in.DeepEqual(other)
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Items != nil) && (other.Items != nil)) || ((in.Items == nil) != (other.Items == nil)) {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqualLocked is an autogenerated deepequal function, comparing the
+// receiver with other like DeepEqual while holding the lock in the
+// mu field of both. The locks are taken in address order, and a lock
+// shared by both only once, so that concurrent comparisons in both
+// directions cannot deadlock. in must be non-nil.
+func (in *Ttest) DeepEqualLocked(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+	first, second := &in.mu, &other.mu
+	if first == second {
+		first.RLock()
+		defer first.RUnlock()
+		return in.DeepEqual(other)
+	}
+
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.RLock()
+	defer first.RUnlock()
+	second.RLock()
+	defer second.RUnlock()
+
+	return in.DeepEqual(other)
+}
@@ -0,0 +1,84 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package matches
+
+import (
+	"testing"
+)
+
+func TestDeepMatches(t *testing.T) {
+	count, other := 1, 2
+	tcp := "TCP"
+	newActual := func() Ttest {
+		return Ttest{
+			Name:    "a",
+			Enabled: true,
+			Count:   &count,
+			Ports: []Port{
+				{Name: "http", Number: 80, Protocol: &tcp},
+				{Name: "https", Number: 443},
+			},
+			Aliases: []string{"x", "y", "z"},
+			Members: []Nested{{Name: "a"}, {Name: "b"}},
+			Tags:    Tags{"t1", "t2"},
+			Labels:  map[string]string{"app": "a", "tier": "web"},
+			Nested:  Nested{Name: "n", Labels: map[string]string{"k": "v"}},
+			Ref:     &Nested{Name: "r"},
+			Byname:  map[string]Nested{"n": {Name: "n", Labels: map[string]string{"k": "v"}}},
+			Shape:   Square(2),
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		expected Ttest
+		match    bool
+	}{
+		{"empty", Ttest{}, true},
+		{"builtin", Ttest{Name: "a", Enabled: true}, true},
+		{"different builtin", Ttest{Name: "b"}, false},
+		{"pointer", Ttest{Count: &count}, true},
+		{"different pointer", Ttest{Count: &other}, false},
+		{"slice prefix", Ttest{Ports: []Port{{Name: "http"}}}, true},
+		{"slice element wildcard", Ttest{Ports: []Port{{}, {Number: 443}}}, true},
+		{"slice not a prefix", Ttest{Ports: []Port{{Name: "https"}}}, false},
+		{"slice too long", Ttest{Ports: []Port{{}, {}, {}}}, false},
+		{"unordered subset", Ttest{Aliases: []string{"z", "x"}}, true},
+		{"unordered not a subset", Ttest{Aliases: []string{"w"}}, false},
+		{"unordered duplicates", Ttest{Aliases: []string{"x", "x"}}, false},
+		{"unordered named slice", Ttest{Tags: Tags{"t2"}}, true},
+		{"unordered wildcard first", Ttest{Members: []Nested{{}, {Name: "a"}}}, true},
+		{"unordered wildcards", Ttest{Members: []Nested{{}, {}}}, true},
+		{"unordered too many wildcards", Ttest{Members: []Nested{{}, {}, {}}}, false},
+		{"unordered wildcard and missing", Ttest{Members: []Nested{{}, {Name: "c"}}}, false},
+		{"map subset", Ttest{Labels: map[string]string{"app": "a"}}, true},
+		{"map different value", Ttest{Labels: map[string]string{"app": "b"}}, false},
+		{"map missing key", Ttest{Labels: map[string]string{"zone": "a"}}, false},
+		{"nested struct", Ttest{Nested: Nested{Labels: map[string]string{"k": "v"}}}, true},
+		{"nested struct mismatch", Ttest{Nested: Nested{Name: "m"}}, false},
+		{"nested pointer", Ttest{Ref: &Nested{Name: "r"}}, true},
+		{"nested pointer wildcard", Ttest{Ref: &Nested{}}, true},
+		{"map of structs", Ttest{Byname: map[string]Nested{"n": {Name: "n"}}}, true},
+		{"map of structs mismatch", Ttest{Byname: map[string]Nested{"n": {Name: "m"}}}, false},
+		{"interface", Ttest{Shape: Square(2)}, true},
+		{"different interface", Ttest{Shape: Square(3)}, false},
+	}
+
+	for _, tc := range testCases {
+		actual := newActual()
+		if got := tc.expected.DeepMatches(&actual); got != tc.match {
+			t.Errorf("%s: expected DeepMatches %t, got %t", tc.name, tc.match, got)
+		}
+	}
+
+	empty := Ttest{}
+	if (&Ttest{Name: "a"}).DeepMatches(&empty) {
+		t.Errorf("expected a set field not to match a zero field")
+	}
+	if (&Ttest{}).DeepMatches(nil) {
+		t.Errorf("expected nil not to match")
+	}
+}
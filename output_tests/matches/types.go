@@ -0,0 +1,48 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package matches
+
+type Shape interface {
+	Area() int
+}
+
+type Square int
+
+func (s Square) Area() int {
+	return int(s) * int(s)
+}
+
+// +deepequal-gen:unordered-array=true
+type Tags []string
+
+type Port struct {
+	Name     string
+	Number   int32
+	Protocol *string
+}
+
+type Nested struct {
+	Name   string
+	Labels map[string]string
+}
+
+// +deepequal-gen:matches=true
+type Ttest struct {
+	Name    string
+	Enabled bool
+	Count   *int
+	Ports   []Port
+	// +deepequal-gen:unordered-array=true
+	Aliases []string
+	// +deepequal-gen:unordered-array=true
+	Members []Nested
+	Tags    Tags
+	Labels  map[string]string
+	Nested  Nested
+	Ref     *Nested
+	Byname  map[string]Nested
+	Shape   Shape
+}
@@ -0,0 +1,475 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package matches
+
+import (
+	reflect "reflect"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Nested) DeepEqual(other *Nested) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepMatches is an autogenerated deepequal function, reporting whether actual
+// matches the receiver.  Zero values in the receiver match any value, slices
+// match as prefixes, or as subsets if they are unordered, and maps as
+// subsets.  in must be non-nil.
+func (in *Nested) DeepMatches(actual *Nested) bool {
+	if actual == nil {
+		return false
+	}
+
+	if in.Name != "" && in.Name != actual.Name {
+		return false
+	}
+	if len(in.Labels) != 0 {
+		for key, inValue := range in.Labels {
+			actualValue, present := actual.Labels[key]
+			if !present {
+				return false
+			}
+			if inValue != actualValue {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Port) DeepEqual(other *Port) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Number != other.Number {
+		return false
+	}
+	if (in.Protocol == nil) != (other.Protocol == nil) {
+		return false
+	} else if in.Protocol != nil {
+		if *in.Protocol != *other.Protocol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepMatches is an autogenerated deepequal function, reporting whether actual
+// matches the receiver.  Zero values in the receiver match any value, slices
+// match as prefixes, or as subsets if they are unordered, and maps as
+// subsets.  in must be non-nil.
+func (in *Port) DeepMatches(actual *Port) bool {
+	if actual == nil {
+		return false
+	}
+
+	if in.Name != "" && in.Name != actual.Name {
+		return false
+	}
+	if in.Number != 0 && in.Number != actual.Number {
+		return false
+	}
+	if in.Protocol != nil {
+		if actual.Protocol == nil {
+			return false
+		}
+		if *in.Protocol != *actual.Protocol {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Tags) DeepEqual(other *Tags) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for _, inElement := range *in {
+			found := false
+			for _, otherElement := range *other {
+				if inElement == otherElement {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepMatches is an autogenerated deepequal function, reporting whether actual
+// matches the receiver.  Zero values in the receiver match any value, slices
+// match as prefixes, or as subsets if they are unordered, and maps as
+// subsets.  in must be non-nil.
+func (in *Tags) DeepMatches(actual *Tags) bool {
+	if actual == nil {
+		return false
+	}
+
+	if len((*in)) > len((*actual)) {
+		return false
+	}
+	owners := make([]int, len((*actual)))
+	var assign func(i int, seen []bool) bool
+	assign = func(i int, seen []bool) bool {
+		for j := range *actual {
+			if seen[j] {
+				continue
+			}
+			if (*in)[i] == (*actual)[j] {
+				seen[j] = true
+				if owners[j] == 0 || assign(owners[j]-1, seen) {
+					owners[j] = i + 1
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for i := range *in {
+		if !assign(i, make([]bool, len((*actual)))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Enabled != other.Enabled {
+		return false
+	}
+	if (in.Count == nil) != (other.Count == nil) {
+		return false
+	} else if in.Count != nil {
+		if *in.Count != *other.Count {
+			return false
+		}
+	}
+
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Aliases != nil) && (other.Aliases != nil)) || ((in.Aliases == nil) != (other.Aliases == nil)) {
+		in, other := &in.Aliases, &other.Aliases
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement == otherElement {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Members != nil) && (other.Members != nil)) || ((in.Members == nil) != (other.Members == nil)) {
+		in, other := &in.Members, &other.Members
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement.DeepEqual(&otherElement) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if !in.Nested.DeepEqual(&other.Nested) {
+		return false
+	}
+
+	if (in.Ref == nil) != (other.Ref == nil) {
+		return false
+	} else if in.Ref != nil {
+		if !in.Ref.DeepEqual(other.Ref) {
+			return false
+		}
+	}
+
+	if ((in.Byname != nil) && (other.Byname != nil)) || ((in.Byname == nil) != (other.Byname == nil)) {
+		in, other := &in.Byname, &other.Byname
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(in.Shape, other.Shape) {
+		return false
+	}
+
+	return true
+}
+
+// DeepMatches is an autogenerated deepequal function, reporting whether actual
+// matches the receiver.  Zero values in the receiver match any value, slices
+// match as prefixes, or as subsets if they are unordered, and maps as
+// subsets.  in must be non-nil.
+func (in *Ttest) DeepMatches(actual *Ttest) bool {
+	if actual == nil {
+		return false
+	}
+
+	if in.Name != "" && in.Name != actual.Name {
+		return false
+	}
+	if in.Enabled && !actual.Enabled {
+		return false
+	}
+	if in.Count != nil {
+		if actual.Count == nil {
+			return false
+		}
+		if *in.Count != *actual.Count {
+			return false
+		}
+	}
+	if len(in.Ports) != 0 {
+		if len(in.Ports) > len(actual.Ports) {
+			return false
+		}
+		for i := range in.Ports {
+			if !in.Ports[i].DeepMatches(&actual.Ports[i]) {
+				return false
+			}
+		}
+	}
+	if len(in.Aliases) != 0 {
+		if len(in.Aliases) > len(actual.Aliases) {
+			return false
+		}
+		owners := make([]int, len(actual.Aliases))
+		var assign func(i int, seen []bool) bool
+		assign = func(i int, seen []bool) bool {
+			for j := range actual.Aliases {
+				if seen[j] {
+					continue
+				}
+				if in.Aliases[i] == actual.Aliases[j] {
+					seen[j] = true
+					if owners[j] == 0 || assign(owners[j]-1, seen) {
+						owners[j] = i + 1
+						return true
+					}
+				}
+			}
+			return false
+		}
+		for i := range in.Aliases {
+			if !assign(i, make([]bool, len(actual.Aliases))) {
+				return false
+			}
+		}
+	}
+	if len(in.Members) != 0 {
+		if len(in.Members) > len(actual.Members) {
+			return false
+		}
+		owners := make([]int, len(actual.Members))
+		var assign func(i int, seen []bool) bool
+		assign = func(i int, seen []bool) bool {
+			for j := range actual.Members {
+				if seen[j] {
+					continue
+				}
+				if in.Members[i].DeepMatches(&actual.Members[j]) {
+					seen[j] = true
+					if owners[j] == 0 || assign(owners[j]-1, seen) {
+						owners[j] = i + 1
+						return true
+					}
+				}
+			}
+			return false
+		}
+		for i := range in.Members {
+			if !assign(i, make([]bool, len(actual.Members))) {
+				return false
+			}
+		}
+	}
+	if !in.Tags.DeepMatches(&actual.Tags) {
+		return false
+	}
+	if len(in.Labels) != 0 {
+		for key, inValue := range in.Labels {
+			actualValue, present := actual.Labels[key]
+			if !present {
+				return false
+			}
+			if inValue != actualValue {
+				return false
+			}
+		}
+	}
+	if !in.Nested.DeepMatches(&actual.Nested) {
+		return false
+	}
+	if in.Ref != nil {
+		if actual.Ref == nil {
+			return false
+		}
+		if !in.Ref.DeepMatches(actual.Ref) {
+			return false
+		}
+	}
+	if len(in.Byname) != 0 {
+		for key, inValue := range in.Byname {
+			actualValue, present := actual.Byname[key]
+			if !present {
+				return false
+			}
+			if !inValue.DeepMatches(&actualValue) {
+				return false
+			}
+		}
+	}
+	if in.Shape != nil {
+		if !reflect.DeepEqual(in.Shape, actual.Shape) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,108 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package mergepatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDeepMergePatch(t *testing.T) {
+	three, five := int32(3), int32(5)
+	cases := []struct {
+		name     string
+		in       Ttest
+		modified Ttest
+		patch    string
+	}{
+		{
+			name:     "equal",
+			in:       Ttest{Meta: Meta{Name: "a"}, Spec: Spec{Replicas: &three}},
+			modified: Ttest{Meta: Meta{Name: "a"}, Spec: Spec{Replicas: &three}},
+			patch:    `{}`,
+		},
+		{
+			name:     "inline fields",
+			in:       Ttest{Meta: Meta{Name: "a", Labels: map[string]string{"app": "x", "tier": "web"}}},
+			modified: Ttest{Meta: Meta{Name: "b", Labels: map[string]string{"app": "y"}}},
+			patch:    `{"name":"b","labels":{"app":"y","tier":null}}`,
+		},
+		{
+			name:     "nested fields",
+			in:       Ttest{Spec: Spec{Replicas: &three, Version: 1}},
+			modified: Ttest{Spec: Spec{Replicas: &five, Version: 2}},
+			patch:    `{"spec":{"replicas":5,"version":"2"}}`,
+		},
+		{
+			name:     "omitempty zero values",
+			in:       Ttest{Meta: Meta{Labels: map[string]string{"app": "x"}}, Spec: Spec{Replicas: &three, Paused: true}, Status: &Status{}},
+			modified: Ttest{},
+			patch:    `{"labels":null,"spec":{"replicas":null,"paused":null},"status":null}`,
+		},
+		{
+			name:     "slices replaced",
+			in:       Ttest{Spec: Spec{Ports: []Port{{Name: "a"}, {Name: "b"}}}},
+			modified: Ttest{Spec: Spec{Ports: []Port{{Name: "a"}, {Name: "c"}}}},
+			patch:    `{"spec":{"ports":[{"name":"a","number":0},{"name":"c","number":0}]}}`,
+		},
+		{
+			name:     "unordered array",
+			in:       Ttest{Spec: Spec{Ports: []Port{{Name: "a"}, {Name: "b"}}}},
+			modified: Ttest{Spec: Spec{Ports: []Port{{Name: "b"}, {Name: "a"}}}},
+			patch:    `{}`,
+		},
+		{
+			name:     "map of structs",
+			in:       Ttest{Spec: Spec{Routes: map[string]Port{"a": {Name: "a", Number: 1}}}},
+			modified: Ttest{Spec: Spec{Routes: map[string]Port{"a": {Name: "a", Number: 2}}}},
+			patch:    `{"spec":{"routes":{"a":{"number":2}}}}`,
+		},
+		{
+			name:     "pointer set",
+			in:       Ttest{},
+			modified: Ttest{Status: &Status{Ready: true}},
+			patch:    `{"status":{"ready":true}}`,
+		},
+		{
+			name:     "pointer patched",
+			in:       Ttest{Status: &Status{Ready: true, Message: "up"}},
+			modified: Ttest{Status: &Status{Ready: true}},
+			patch:    `{"status":{"message":null}}`,
+		},
+		{
+			name:     "interface",
+			in:       Ttest{Extra: map[string]interface{}{"a": 1, "b": 2}},
+			modified: Ttest{Extra: map[string]interface{}{"a": 1, "b": 3}},
+			patch:    `{"extra":{"b":3}}`,
+		},
+		{
+			name:     "untagged and ignored fields",
+			in:       Ttest{Revision: 1, Internal: "a"},
+			modified: Ttest{Revision: 2, Internal: "b"},
+			patch:    `{"Revision":2}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			patch, err := c.in.DeepMergePatch(&c.modified)
+			if err != nil {
+				t.Fatalf("DeepMergePatch() failed: %v", err)
+			}
+			var got, expected interface{}
+			if err := json.Unmarshal(patch, &got); err != nil {
+				t.Fatalf("DeepMergePatch() returned invalid JSON %s: %v", patch, err)
+			}
+			if err := json.Unmarshal([]byte(c.patch), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("DeepMergePatch() = %s, expected %s", patch, c.patch)
+			}
+		})
+	}
+}
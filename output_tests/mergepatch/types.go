@@ -0,0 +1,40 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package mergepatch
+
+type Meta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type Port struct {
+	Name   string `json:"name"`
+	Number int32  `json:"number"`
+}
+
+type Spec struct {
+	Replicas *int32 `json:"replicas,omitempty"`
+	Paused   bool   `json:"paused,omitempty"`
+	// +deepequal-gen:unordered-array=true
+	Ports   []Port          `json:"ports"`
+	Routes  map[string]Port `json:"routes,omitempty"`
+	Version int64           `json:"version,string"`
+}
+
+type Status struct {
+	Ready   bool   `json:"ready"`
+	Message string `json:"message,omitempty"`
+}
+
+// +deepequal-gen:merge-patch=true
+type Ttest struct {
+	Meta     `json:",inline"`
+	Spec     Spec        `json:"spec"`
+	Status   *Status     `json:"status,omitempty"`
+	Extra    interface{} `json:"extra,omitempty"`
+	Revision int64
+	Internal string `json:"-"`
+}
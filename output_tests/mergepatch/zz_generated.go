@@ -0,0 +1,433 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package mergepatch
+
+import (
+	reflect "reflect"
+
+	deepequal "github.com/wind-river/deepequal-gen/deepequal"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Meta) DeepEqual(other *Meta) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepMergePatch is an autogenerated deepequal function, returning the RFC 7386
+// JSON merge patch turning the JSON encoding of the receiver into that of
+// modified. Fields which DeepEqual considers equal are left out of the patch,
+// and slices are replaced as a whole. in and modified must be non-nil.
+func (in *Meta) DeepMergePatch(modified *Meta) ([]byte, error) {
+	other := modified
+	patch := deepequal.MergePatch{}
+
+	if in.Name != other.Name {
+		if err := patch.Set("name", other.Name); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+			in, other := &in.Labels, &other.Labels
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for key, inValue := range *in {
+					if otherValue, present := (*other)[key]; !present {
+						return false
+					} else {
+						if inValue != otherValue {
+							return false
+						}
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		if len(other.Labels) == 0 {
+			patch.Remove("labels")
+		} else if err := patch.Diff("labels", in.Labels, other.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch.Marshal()
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Port) DeepEqual(other *Port) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Number != other.Number {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Spec) DeepEqual(other *Spec) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Replicas == nil) != (other.Replicas == nil) {
+		return false
+	} else if in.Replicas != nil {
+		if *in.Replicas != *other.Replicas {
+			return false
+		}
+	}
+
+	if in.Paused != other.Paused {
+		return false
+	}
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement.DeepEqual(&otherElement) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Routes != nil) && (other.Routes != nil)) || ((in.Routes == nil) != (other.Routes == nil)) {
+		in, other := &in.Routes, &other.Routes
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if in.Version != other.Version {
+		return false
+	}
+
+	return true
+}
+
+// DeepMergePatch is an autogenerated deepequal function, returning the RFC 7386
+// JSON merge patch turning the JSON encoding of the receiver into that of
+// modified. Fields which DeepEqual considers equal are left out of the patch,
+// and slices are replaced as a whole. in and modified must be non-nil.
+func (in *Spec) DeepMergePatch(modified *Spec) ([]byte, error) {
+	other := modified
+	patch := deepequal.MergePatch{}
+
+	if !func() bool {
+		if (in.Replicas == nil) != (other.Replicas == nil) {
+			return false
+		} else if in.Replicas != nil {
+			if *in.Replicas != *other.Replicas {
+				return false
+			}
+		}
+		return true
+	}() {
+		if other.Replicas == nil {
+			patch.Remove("replicas")
+		} else if err := patch.Set("replicas", other.Replicas); err != nil {
+			return nil, err
+		}
+	}
+	if in.Paused != other.Paused {
+		if !other.Paused {
+			patch.Remove("paused")
+		} else if err := patch.Set("paused", other.Paused); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+			in, other := &in.Ports, &other.Ports
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for _, inElement := range *in {
+					found := false
+					for _, otherElement := range *other {
+						if inElement.DeepEqual(&otherElement) {
+							found = true
+							break
+						}
+					}
+					if !found {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		if err := patch.Set("ports", other.Ports); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if ((in.Routes != nil) && (other.Routes != nil)) || ((in.Routes == nil) != (other.Routes == nil)) {
+			in, other := &in.Routes, &other.Routes
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for key, inValue := range *in {
+					if otherValue, present := (*other)[key]; !present {
+						return false
+					} else {
+						if !inValue.DeepEqual(&otherValue) {
+							return false
+						}
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		if len(other.Routes) == 0 {
+			patch.Remove("routes")
+		} else if err := patch.Diff("routes", in.Routes, other.Routes); err != nil {
+			return nil, err
+		}
+	}
+	if in.Version != other.Version {
+		if err := patch.SetQuoted("version", other.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch.Marshal()
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Status) DeepEqual(other *Status) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Ready != other.Ready {
+		return false
+	}
+	if in.Message != other.Message {
+		return false
+	}
+
+	return true
+}
+
+// DeepMergePatch is an autogenerated deepequal function, returning the RFC 7386
+// JSON merge patch turning the JSON encoding of the receiver into that of
+// modified. Fields which DeepEqual considers equal are left out of the patch,
+// and slices are replaced as a whole. in and modified must be non-nil.
+func (in *Status) DeepMergePatch(modified *Status) ([]byte, error) {
+	other := modified
+	patch := deepequal.MergePatch{}
+
+	if in.Ready != other.Ready {
+		if err := patch.Set("ready", other.Ready); err != nil {
+			return nil, err
+		}
+	}
+	if in.Message != other.Message {
+		if other.Message == "" {
+			patch.Remove("message")
+		} else if err := patch.Set("message", other.Message); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch.Marshal()
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.Meta.DeepEqual(&other.Meta) {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if (in.Status == nil) != (other.Status == nil) {
+		return false
+	} else if in.Status != nil {
+		if !in.Status.DeepEqual(other.Status) {
+			return false
+		}
+	}
+
+	if !reflect.DeepEqual(in.Extra, other.Extra) {
+		return false
+	}
+
+	if in.Revision != other.Revision {
+		return false
+	}
+	if in.Internal != other.Internal {
+		return false
+	}
+
+	return true
+}
+
+// DeepMergePatch is an autogenerated deepequal function, returning the RFC 7386
+// JSON merge patch turning the JSON encoding of the receiver into that of
+// modified. Fields which DeepEqual considers equal are left out of the patch,
+// and slices are replaced as a whole. in and modified must be non-nil.
+func (in *Ttest) DeepMergePatch(modified *Ttest) ([]byte, error) {
+	other := modified
+	patch := deepequal.MergePatch{}
+
+	if !func() bool {
+		if !in.Meta.DeepEqual(&other.Meta) {
+			return false
+		}
+		return true
+	}() {
+		if nested, err := in.Meta.DeepMergePatch(&other.Meta); err != nil {
+			return nil, err
+		} else if err := patch.Inline(nested); err != nil {
+			return nil, err
+		}
+	}
+	if !func() bool {
+		if !in.Spec.DeepEqual(&other.Spec) {
+			return false
+		}
+		return true
+	}() {
+		if nested, err := in.Spec.DeepMergePatch(&other.Spec); err != nil {
+			return nil, err
+		} else {
+			patch.Merge("spec", nested)
+		}
+	}
+	if !func() bool {
+		if (in.Status == nil) != (other.Status == nil) {
+			return false
+		} else if in.Status != nil {
+			if !in.Status.DeepEqual(other.Status) {
+				return false
+			}
+		}
+		return true
+	}() {
+		if other.Status == nil {
+			patch.Remove("status")
+		} else if in.Status == nil {
+			if err := patch.Set("status", other.Status); err != nil {
+				return nil, err
+			}
+		} else if nested, err := in.Status.DeepMergePatch(other.Status); err != nil {
+			return nil, err
+		} else {
+			patch.Merge("status", nested)
+		}
+	}
+	if !func() bool {
+		if !reflect.DeepEqual(in.Extra, other.Extra) {
+			return false
+		}
+		return true
+	}() {
+		if other.Extra == nil {
+			patch.Remove("extra")
+		} else if err := patch.Diff("extra", in.Extra, other.Extra); err != nil {
+			return nil, err
+		}
+	}
+	if in.Revision != other.Revision {
+		if err := patch.Set("Revision", other.Revision); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch.Marshal()
+}
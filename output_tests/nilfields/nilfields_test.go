@@ -0,0 +1,66 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package nilfields
+
+import (
+	"testing"
+)
+
+func TestIgnoreNilFieldsEither(t *testing.T) {
+	age, other := 1, 2
+	full := Either{
+		Name:     "a",
+		Age:      &age,
+		Items:    []string{"a"},
+		Labels:   map[string]string{"a": "b"},
+		Shape:    Square(2),
+		Required: &age,
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(x *Either)
+		equal  bool
+	}{
+		{"identical", func(x *Either) {}, true},
+		{"nil pointer", func(x *Either) { x.Age = nil }, true},
+		{"nil slice", func(x *Either) { x.Items = nil }, true},
+		{"nil map", func(x *Either) { x.Labels = nil }, true},
+		{"nil interface", func(x *Either) { x.Shape = nil }, true},
+		{"empty slice", func(x *Either) { x.Items = []string{} }, false},
+		{"different pointer", func(x *Either) { x.Age = &other }, false},
+		{"different interface", func(x *Either) { x.Shape = Square(3) }, false},
+		{"nil pointer with field override", func(x *Either) { x.Required = nil }, false},
+		{"different builtin", func(x *Either) { x.Name = "b" }, false},
+	}
+
+	for _, tc := range testCases {
+		x, y := full, full
+		tc.mutate(&x)
+		if got := x.DeepEqual(&y); got != tc.equal {
+			t.Errorf("%s: expected x.DeepEqual(y) %t, got %t", tc.name, tc.equal, got)
+		}
+		if got := y.DeepEqual(&x); got != tc.equal {
+			t.Errorf("%s: expected y.DeepEqual(x) %t, got %t", tc.name, tc.equal, got)
+		}
+	}
+}
+
+func TestIgnoreNilFieldsLeft(t *testing.T) {
+	age := 1
+	x, y := Left{}, Left{Age: &age, Items: []string{"a"}}
+	if !x.DeepEqual(&y) {
+		t.Errorf("expected nil fields of the receiver to be ignored")
+	}
+	if y.DeepEqual(&x) {
+		t.Errorf("expected nil pointer fields of the argument to be compared")
+	}
+
+	y.Age = nil
+	if !y.DeepEqual(&x) {
+		t.Errorf("expected nil slice fields of either value to be ignored")
+	}
+}
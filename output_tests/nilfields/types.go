@@ -0,0 +1,34 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package nilfields
+
+type Shape interface {
+	Area() int
+}
+
+type Square int
+
+func (s Square) Area() int {
+	return int(s) * int(s)
+}
+
+// +deepequal-gen:ignore-nil-fields=either
+type Either struct {
+	Name   string
+	Age    *int
+	Items  []string
+	Labels map[string]string
+	Shape  Shape
+	// +deepequal-gen:ignore-nil-fields=false
+	Required *int
+}
+
+// +deepequal-gen:ignore-nil-fields=true
+type Left struct {
+	Age *int
+	// +deepequal-gen:ignore-nil-fields=either
+	Items []string
+}
@@ -0,0 +1,124 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package nilfields
+
+import (
+	reflect "reflect"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Either) DeepEqual(other *Either) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Age != nil && other.Age != nil {
+		if (in.Age == nil) != (other.Age == nil) {
+			return false
+		} else if in.Age != nil {
+			if *in.Age != *other.Age {
+				return false
+			}
+		}
+	}
+
+	if in.Items != nil && other.Items != nil {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if in.Labels != nil && other.Labels != nil {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if in.Shape != nil && other.Shape != nil {
+		if !reflect.DeepEqual(in.Shape, other.Shape) {
+			return false
+		}
+	}
+
+	if (in.Required == nil) != (other.Required == nil) {
+		return false
+	} else if in.Required != nil {
+		if *in.Required != *other.Required {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Left) DeepEqual(other *Left) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Age != nil {
+		if (in.Age == nil) != (other.Age == nil) {
+			return false
+		} else if in.Age != nil {
+			if *in.Age != *other.Age {
+				return false
+			}
+		}
+	}
+
+	if in.Items != nil && other.Items != nil {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,90 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeepNormalize(t *testing.T) {
+	one, two := 1, 2
+	newValue := func() Ttest {
+		return Ttest{
+			Ports: Ports{
+				{Name: "https", Number: 443, Aliases: []string{"tls", "secure"}},
+				{Name: "http", Number: 80},
+			},
+			Tags:    []string{"b", "c", "a"},
+			Weights: []*int{&two, nil, &one},
+			Path:    []string{"z", "a"},
+			Nested:  map[string]Port{"a": {Aliases: []string{"y", "x"}}},
+			Primary: &Port{Aliases: []string{"q", "p"}},
+		}
+	}
+
+	x, original := newValue(), newValue()
+	x.DeepNormalize()
+	if !x.DeepEqual(&original) || !original.DeepEqual(&x) {
+		t.Errorf("expected the normalized value to equal the original")
+	}
+
+	expected := Ttest{
+		Ports: Ports{
+			{Name: "http", Number: 80, Aliases: []string{}},
+			{Name: "https", Number: 443, Aliases: []string{"secure", "tls"}},
+		},
+		Tags:    []string{"a", "b", "c"},
+		Weights: []*int{nil, &one, &two},
+		Path:    []string{"z", "a"},
+		Labels:  map[string]string{},
+		Nested:  map[string]Port{"a": {Aliases: []string{"x", "y"}}},
+		Primary: &Port{Aliases: []string{"p", "q"}},
+	}
+	if !reflect.DeepEqual(x, expected) {
+		t.Errorf("expected %+v, got %+v", expected, x)
+	}
+
+	// Values which are DeepEqual normalize to the same representation.
+	y := Ttest{
+		Ports: Ports{
+			{Name: "http", Number: 80, Aliases: []string{}},
+			{Name: "https", Number: 443, Aliases: []string{"secure", "tls"}},
+		},
+		Tags:    []string{"c", "a", "b"},
+		Weights: []*int{&one, &two, nil},
+		Path:    []string{"z", "a"},
+		Labels:  map[string]string{},
+		Nested:  map[string]Port{"a": {Aliases: []string{"x", "y"}}},
+		Primary: &Port{Aliases: []string{"q", "p"}},
+	}
+	y.DeepNormalize()
+	if !reflect.DeepEqual(x, y) {
+		t.Errorf("expected equal values to normalize identically, got %+v and %+v", x, y)
+	}
+}
+
+func TestDeepNormalizeIgnoreNil(t *testing.T) {
+	x := Ttest{}
+	x.DeepNormalize()
+	if x.Optional != nil {
+		t.Errorf("expected fields ignoring nil values to be left untouched")
+	}
+}
+
+func TestDeepNormalizeJSONSemantics(t *testing.T) {
+	x, original := Document{}, Document{}
+	x.DeepNormalize()
+	if x.Items != nil {
+		t.Errorf("expected nil fields encoded as null to be left untouched")
+	}
+	if x.Tags == nil {
+		t.Errorf("expected nil omitempty fields to become empty")
+	}
+	if !x.DeepEqual(&original) || !original.DeepEqual(&x) {
+		t.Errorf("expected the normalized value to equal the original")
+	}
+}
@@ -0,0 +1,40 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package normalize
+
+// +deepequal-gen:compare=true
+type Port struct {
+	Name   string
+	Number int32
+	// +deepequal-gen:unordered-array=true
+	Aliases []string
+}
+
+// +deepequal-gen:unordered-array=true
+type Ports []Port
+
+// +deepequal-gen:normalize=true
+type Ttest struct {
+	Name  string
+	Ports Ports
+	// +deepequal-gen:unordered-array=true
+	Tags []string
+	// +deepequal-gen:unordered-array=true
+	Weights []*int
+	Path    []string
+	Labels  map[string]string
+	Nested  map[string]Port
+	Primary *Port
+	// +deepequal-gen:ignore-nil-fields=either
+	Optional []string
+}
+
+// +deepequal-gen:normalize=true
+// +deepequal-gen:semantics=json
+type Document struct {
+	Items []string          `json:"items"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
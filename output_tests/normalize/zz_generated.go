@@ -0,0 +1,396 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package normalize
+
+import (
+	sort "sort"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Document) DeepEqual(other *Document) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Items == nil) != (other.Items == nil) {
+		return false
+	}
+	if ((in.Items != nil) && (other.Items != nil)) || ((in.Items == nil) != (other.Items == nil)) {
+		in, other := &in.Items, &other.Items
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepNormalize is an autogenerated deepequal function, rewriting the receiver
+// into a canonical form among the values DeepEqual considers equal to it:
+// unordered slices are sorted, and nil slices and maps compared as empty
+// become empty. in must be non-nil.
+func (in *Document) DeepNormalize() {
+	if in.Tags == nil {
+		in.Tags = map[string]string{}
+	}
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Port) DeepEqual(other *Port) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Number != other.Number {
+		return false
+	}
+	if ((in.Aliases != nil) && (other.Aliases != nil)) || ((in.Aliases == nil) != (other.Aliases == nil)) {
+		in, other := &in.Aliases, &other.Aliases
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			inSorted := append([]string(nil), (*in)...)
+			otherSorted := append([]string(nil), (*other)...)
+			sort.Slice(inSorted, func(i, j int) bool { return inSorted[i] < inSorted[j] })
+			sort.Slice(otherSorted, func(i, j int) bool { return otherSorted[i] < otherSorted[j] })
+			for i := range inSorted {
+				if inSorted[i] != otherSorted[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when DeepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *Port) DeepCompare(other *Port) int {
+	if other == nil {
+		return 1
+	}
+
+	if in.Name != other.Name {
+		if in.Name < other.Name {
+			return -1
+		}
+		return 1
+	}
+	if in.Number != other.Number {
+		if in.Number < other.Number {
+			return -1
+		}
+		return 1
+	}
+	{
+		compare := func(in, other *string) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		inSorted := append([]string(nil), in.Aliases...)
+		otherSorted := append([]string(nil), other.Aliases...)
+		sort.Slice(inSorted, func(i, j int) bool { return compare(&inSorted[i], &inSorted[j]) < 0 })
+		sort.Slice(otherSorted, func(i, j int) bool { return compare(&otherSorted[i], &otherSorted[j]) < 0 })
+		for i := 0; i < len(inSorted) && i < len(otherSorted); i++ {
+			if c := compare(&inSorted[i], &otherSorted[i]); c != 0 {
+				return c
+			}
+		}
+		if len(inSorted) != len(otherSorted) {
+			if len(inSorted) < len(otherSorted) {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// DeepNormalize is an autogenerated deepequal function, rewriting the receiver
+// into a canonical form among the values DeepEqual considers equal to it:
+// unordered slices are sorted, and nil slices and maps compared as empty
+// become empty. in must be non-nil.
+func (in *Port) DeepNormalize() {
+	if in.Aliases == nil {
+		in.Aliases = []string{}
+	}
+	sort.SliceStable(in.Aliases, func(i, j int) bool { return in.Aliases[i] < in.Aliases[j] })
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ports) DeepEqual(other *Ports) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		inSorted := append([]Port(nil), (*in)...)
+		otherSorted := append([]Port(nil), (*other)...)
+		sort.Slice(inSorted, func(i, j int) bool { return inSorted[i].DeepCompare(&inSorted[j]) < 0 })
+		sort.Slice(otherSorted, func(i, j int) bool { return otherSorted[i].DeepCompare(&otherSorted[j]) < 0 })
+		for i := range inSorted {
+			if inSorted[i].DeepCompare(&otherSorted[i]) != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepNormalize is an autogenerated deepequal function, rewriting the receiver
+// into a canonical form among the values DeepEqual considers equal to it:
+// unordered slices are sorted, and nil slices and maps compared as empty
+// become empty. in must be non-nil.
+func (in *Ports) DeepNormalize() {
+	for i := range *in {
+		(*in)[i].DeepNormalize()
+	}
+	sort.SliceStable((*in), func(i, j int) bool { return (*in)[i].DeepCompare(&(*in)[j]) < 0 })
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if inElement == otherElement {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Weights != nil) && (other.Weights != nil)) || ((in.Weights == nil) != (other.Weights == nil)) {
+		in, other := &in.Weights, &other.Weights
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if (inElement == nil) && (otherElement == nil) || ((inElement != nil) && (otherElement != nil) && (*inElement == *otherElement)) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Path != nil) && (other.Path != nil)) || ((in.Path == nil) != (other.Path == nil)) {
+		in, other := &in.Path, &other.Path
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if ((in.Nested != nil) && (other.Nested != nil)) || ((in.Nested == nil) != (other.Nested == nil)) {
+		in, other := &in.Nested, &other.Nested
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if (in.Primary == nil) != (other.Primary == nil) {
+		return false
+	} else if in.Primary != nil {
+		if !in.Primary.DeepEqual(other.Primary) {
+			return false
+		}
+	}
+
+	if in.Optional != nil && other.Optional != nil {
+		in, other := &in.Optional, &other.Optional
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepNormalize is an autogenerated deepequal function, rewriting the receiver
+// into a canonical form among the values DeepEqual considers equal to it:
+// unordered slices are sorted, and nil slices and maps compared as empty
+// become empty. in must be non-nil.
+func (in *Ttest) DeepNormalize() {
+	if in.Ports == nil {
+		in.Ports = Ports{}
+	}
+	in.Ports.DeepNormalize()
+	if in.Tags == nil {
+		in.Tags = []string{}
+	}
+	sort.SliceStable(in.Tags, func(i, j int) bool { return in.Tags[i] < in.Tags[j] })
+	if in.Weights == nil {
+		in.Weights = []*int{}
+	}
+	sort.SliceStable(in.Weights, func(i, j int) bool {
+		return in.Weights[j] != nil && (in.Weights[i] == nil || *in.Weights[i] < *in.Weights[j])
+	})
+	if in.Path == nil {
+		in.Path = []string{}
+	}
+	if in.Labels == nil {
+		in.Labels = map[string]string{}
+	}
+	if in.Nested == nil {
+		in.Nested = map[string]Port{}
+	}
+	for key, value := range in.Nested {
+		value.DeepNormalize()
+		in.Nested[key] = value
+	}
+	if in.Primary != nil {
+		in.Primary.DeepNormalize()
+	}
+}
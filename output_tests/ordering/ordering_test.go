@@ -0,0 +1,93 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package ordering
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestDeepCompare(t *testing.T) {
+	one, two := 1.0, 2.0
+	values := []Ttest{
+		{},
+		{Name: "a"},
+		{Name: "b"},
+		{Name: "a", Weight: &one},
+		{Name: "a", Weight: &two},
+		{Ports: Ports{{Name: "http", Number: 80}}},
+		{Ports: Ports{{Name: "http", Number: 80}, {Name: "https", Number: 443}}},
+		{Ports: Ports{{Name: "https", Number: 443}, {Name: "http", Number: 80, Public: true}}},
+		{Tags: []string{"a", "a", "b"}},
+		{Tags: []string{"a", "b", "b"}},
+		{Path: []string{"a", "b"}},
+		{Path: []string{"b", "a"}},
+		{Path: []string{"a"}},
+		{Labels: map[string]string{"a": "1"}},
+		{Labels: map[string]string{"a": "2"}},
+		{Labels: map[string]string{"b": "1"}},
+		{Labels: map[string]string{"a": "1", "b": "1"}},
+		{Nested: map[string]*Port{"a": {Name: "b"}}},
+		{Nested: map[string]*Port{"a": {Name: "a"}}},
+	}
+
+	for i := range values {
+		for j := range values {
+			x, y := values[i], values[j]
+			c, equal := x.DeepCompare(&y), x.DeepEqual(&y)
+			if (c == 0) != equal {
+				t.Errorf("values[%d] and values[%d]: DeepCompare returned %d but DeepEqual returned %t", i, j, c, equal)
+			}
+			if r := y.DeepCompare(&x); sign(r) != -sign(c) {
+				t.Errorf("values[%d] and values[%d]: DeepCompare is not antisymmetric: %d and %d", i, j, c, r)
+			}
+			if i != j && equal {
+				t.Errorf("values[%d] and values[%d]: expected distinct values", i, j)
+			}
+		}
+	}
+
+	// Sorting by DeepCompare yields the same order from any permutation.
+	sorted := append([]Ttest(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].DeepCompare(&sorted[j]) < 0 })
+	reversed := make([]Ttest, len(values))
+	for i := range values {
+		reversed[len(values)-1-i] = values[i]
+	}
+	sort.Slice(reversed, func(i, j int) bool { return reversed[i].DeepCompare(&reversed[j]) < 0 })
+	for i := range sorted {
+		if !sorted[i].DeepEqual(&reversed[i]) {
+			t.Errorf("sorted[%d]: expected the same order from any permutation", i)
+		}
+	}
+}
+
+func TestUnorderedEqual(t *testing.T) {
+	x := Ttest{
+		Ports: Ports{{Name: "http", Number: 80}, {Name: "https", Number: 443}},
+		Tags:  []string{"a", "b"},
+	}
+	y := Ttest{
+		Ports: Ports{{Name: "https", Number: 443}, {Name: "http", Number: 80}},
+		Tags:  []string{"b", "a"},
+	}
+	if !x.DeepEqual(&y) || x.DeepCompare(&y) != 0 {
+		t.Errorf("expected reordered unordered slices to be equal")
+	}
+	if x.Ports.DeepEqual(&Ports{{Name: "http", Number: 80}, {Name: "http", Number: 80}}) {
+		t.Errorf("expected unordered slices with different duplicates to differ")
+	}
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package ordering
+
+type Port struct {
+	Name   string
+	Number int32
+	Public bool
+}
+
+// +deepequal-gen:unordered-array=true
+type Ports []Port
+
+// +deepequal-gen:compare=true
+type Ttest struct {
+	Name   string
+	Weight *float64
+	Ports  Ports
+	// +deepequal-gen:unordered-array=true
+	Tags   []string
+	Path   []string
+	Labels map[string]string
+	Nested map[string]*Port
+}
@@ -0,0 +1,398 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package ordering
+
+import (
+	sort "sort"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Port) DeepEqual(other *Port) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.Number != other.Number {
+		return false
+	}
+	if in.Public != other.Public {
+		return false
+	}
+
+	return true
+}
+
+// DeepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when DeepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *Port) DeepCompare(other *Port) int {
+	if other == nil {
+		return 1
+	}
+
+	if in.Name != other.Name {
+		if in.Name < other.Name {
+			return -1
+		}
+		return 1
+	}
+	if in.Number != other.Number {
+		if in.Number < other.Number {
+			return -1
+		}
+		return 1
+	}
+	if in.Public != other.Public {
+		if !in.Public {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ports) DeepEqual(other *Ports) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		inSorted := append([]Port(nil), (*in)...)
+		otherSorted := append([]Port(nil), (*other)...)
+		sort.Slice(inSorted, func(i, j int) bool { return inSorted[i].DeepCompare(&inSorted[j]) < 0 })
+		sort.Slice(otherSorted, func(i, j int) bool { return otherSorted[i].DeepCompare(&otherSorted[j]) < 0 })
+		for i := range inSorted {
+			if inSorted[i].DeepCompare(&otherSorted[i]) != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when DeepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *Ports) DeepCompare(other *Ports) int {
+	if other == nil {
+		return 1
+	}
+
+	compare := func(in, other *Port) int {
+		if c := (*in).DeepCompare(&(*other)); c != 0 {
+			return c
+		}
+		return 0
+	}
+	inSorted := append([]Port(nil), (*in)...)
+	otherSorted := append([]Port(nil), (*other)...)
+	sort.Slice(inSorted, func(i, j int) bool { return compare(&inSorted[i], &inSorted[j]) < 0 })
+	sort.Slice(otherSorted, func(i, j int) bool { return compare(&otherSorted[i], &otherSorted[j]) < 0 })
+	for i := 0; i < len(inSorted) && i < len(otherSorted); i++ {
+		if c := compare(&inSorted[i], &otherSorted[i]); c != 0 {
+			return c
+		}
+	}
+	if len(inSorted) != len(otherSorted) {
+		if len(inSorted) < len(otherSorted) {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if (in.Weight == nil) != (other.Weight == nil) {
+		return false
+	} else if in.Weight != nil {
+		if *in.Weight != *other.Weight {
+			return false
+		}
+	}
+
+	if ((in.Ports != nil) && (other.Ports != nil)) || ((in.Ports == nil) != (other.Ports == nil)) {
+		in, other := &in.Ports, &other.Ports
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	if ((in.Tags != nil) && (other.Tags != nil)) || ((in.Tags == nil) != (other.Tags == nil)) {
+		in, other := &in.Tags, &other.Tags
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			inSorted := append([]string(nil), (*in)...)
+			otherSorted := append([]string(nil), (*other)...)
+			sort.Slice(inSorted, func(i, j int) bool { return inSorted[i] < inSorted[j] })
+			sort.Slice(otherSorted, func(i, j int) bool { return otherSorted[i] < otherSorted[j] })
+			for i := range inSorted {
+				if inSorted[i] != otherSorted[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Path != nil) && (other.Path != nil)) || ((in.Path == nil) != (other.Path == nil)) {
+		in, other := &in.Path, &other.Path
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if ((in.Nested != nil) && (other.Nested != nil)) || ((in.Nested == nil) != (other.Nested == nil)) {
+		in, other := &in.Nested, &other.Nested
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.DeepEqual(otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepCompare is an autogenerated deepequal function, ordering the receiver
+// and other field by field.  It returns 0 exactly when DeepEqual reports them
+// equal, and a negative or positive number when the receiver sorts before or
+// after other. in must be non-nil.
+func (in *Ttest) DeepCompare(other *Ttest) int {
+	if other == nil {
+		return 1
+	}
+
+	if in.Name != other.Name {
+		if in.Name < other.Name {
+			return -1
+		}
+		return 1
+	}
+	if (in.Weight == nil) != (other.Weight == nil) {
+		if in.Weight == nil {
+			return -1
+		}
+		return 1
+	}
+	if in.Weight != nil {
+		if (*in.Weight) != (*other.Weight) {
+			if (*in.Weight) < (*other.Weight) {
+				return -1
+			}
+			return 1
+		}
+	}
+	if c := in.Ports.DeepCompare(&other.Ports); c != 0 {
+		return c
+	}
+	{
+		compare := func(in, other *string) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		inSorted := append([]string(nil), in.Tags...)
+		otherSorted := append([]string(nil), other.Tags...)
+		sort.Slice(inSorted, func(i, j int) bool { return compare(&inSorted[i], &inSorted[j]) < 0 })
+		sort.Slice(otherSorted, func(i, j int) bool { return compare(&otherSorted[i], &otherSorted[j]) < 0 })
+		for i := 0; i < len(inSorted) && i < len(otherSorted); i++ {
+			if c := compare(&inSorted[i], &otherSorted[i]); c != 0 {
+				return c
+			}
+		}
+		if len(inSorted) != len(otherSorted) {
+			if len(inSorted) < len(otherSorted) {
+				return -1
+			}
+			return 1
+		}
+	}
+	{
+		compare := func(in, other *string) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		for i := 0; i < len(in.Path) && i < len(other.Path); i++ {
+			if c := compare(&in.Path[i], &other.Path[i]); c != 0 {
+				return c
+			}
+		}
+		if len(in.Path) != len(other.Path) {
+			if len(in.Path) < len(other.Path) {
+				return -1
+			}
+			return 1
+		}
+	}
+	{
+		compare := func(in, other *string) int {
+			if (*in) != (*other) {
+				if (*in) < (*other) {
+					return -1
+				}
+				return 1
+			}
+			return 0
+		}
+		if len(in.Labels) != len(other.Labels) {
+			if len(in.Labels) < len(other.Labels) {
+				return -1
+			}
+			return 1
+		}
+		keys := make([]string, 0, len(in.Labels))
+		for key := range in.Labels {
+			keys = append(keys, key)
+		}
+		for key := range other.Labels {
+			if _, found := in.Labels[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			inValue, inFound := in.Labels[key]
+			otherValue, otherFound := other.Labels[key]
+			if inFound != otherFound {
+				if !inFound {
+					return -1
+				}
+				return 1
+			}
+			if c := compare(&inValue, &otherValue); c != 0 {
+				return c
+			}
+		}
+	}
+	{
+		compare := func(in, other **Port) int {
+			if ((*in) == nil) != ((*other) == nil) {
+				if (*in) == nil {
+					return -1
+				}
+				return 1
+			}
+			if (*in) != nil {
+				if c := (*(*in)).DeepCompare(&(*(*other))); c != 0 {
+					return c
+				}
+			}
+			return 0
+		}
+		if len(in.Nested) != len(other.Nested) {
+			if len(in.Nested) < len(other.Nested) {
+				return -1
+			}
+			return 1
+		}
+		keys := make([]string, 0, len(in.Nested))
+		for key := range in.Nested {
+			keys = append(keys, key)
+		}
+		for key := range other.Nested {
+			if _, found := in.Nested[key]; !found {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, key := range keys {
+			inValue, inFound := in.Nested[key]
+			otherValue, otherFound := other.Nested[key]
+			if inFound != otherFound {
+				if !inFound {
+					return -1
+				}
+				return 1
+			}
+			if c := compare(&inValue, &otherValue); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,25 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.  Its private types get an unexported deepEqual
+// method which the exported types use.
+package private
+
+type Ttest struct {
+	State    innerState
+	StatePtr *innerState
+	States   []innerState
+	ByName   map[string]innerState
+	Names    names
+}
+
+type innerState struct {
+	Values []string
+}
+
+// +deepequal-gen:unordered-array=true
+type names []string
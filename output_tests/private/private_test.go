@@ -0,0 +1,73 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package private
+
+import (
+	"testing"
+)
+
+func TestPrivate(t *testing.T) {
+	newTtest := func() Ttest {
+		return Ttest{
+			State:    innerState{Values: []string{"a"}},
+			StatePtr: &innerState{Values: []string{"b"}},
+			States:   []innerState{{Values: []string{"c"}}},
+			ByName:   map[string]innerState{"d": {Values: []string{"d"}}},
+			Names:    names{"e", "f"},
+		}
+	}
+	x := newTtest()
+
+	testCases := []struct {
+		name   string
+		mutate func(y *Ttest)
+		equal  bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(y *Ttest) {},
+			equal:  true,
+		},
+		{
+			name:   "state",
+			mutate: func(y *Ttest) { y.State.Values = nil },
+			equal:  false,
+		},
+		{
+			name:   "state pointer",
+			mutate: func(y *Ttest) { y.StatePtr.Values[0] = "x" },
+			equal:  false,
+		},
+		{
+			name:   "states",
+			mutate: func(y *Ttest) { y.States[0].Values = []string{"x"} },
+			equal:  false,
+		},
+		{
+			name:   "map",
+			mutate: func(y *Ttest) { y.ByName["d"] = innerState{} },
+			equal:  false,
+		},
+		{
+			name:   "reordered names",
+			mutate: func(y *Ttest) { y.Names = names{"f", "e"} },
+			equal:  true,
+		},
+		{
+			name:   "names",
+			mutate: func(y *Ttest) { y.Names = names{"e", "x"} },
+			equal:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		y := newTtest()
+		tc.mutate(&y)
+		if got := x.DeepEqual(&y); got != tc.equal {
+			t.Errorf("%s: expected DeepEqual %t, got %t", tc.name, tc.equal, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package private
+
+// deepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *innerState) deepEqual(other *innerState) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Values != nil) && (other.Values != nil)) || ((in.Values == nil) != (other.Values == nil)) {
+		in, other := &in.Values, &other.Values
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// deepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *names) deepEqual(other *names) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for _, inElement := range *in {
+			found := false
+			for _, otherElement := range *other {
+				if inElement == otherElement {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.State.deepEqual(&other.State) {
+		return false
+	}
+
+	if (in.StatePtr == nil) != (other.StatePtr == nil) {
+		return false
+	} else if in.StatePtr != nil {
+		if !in.StatePtr.deepEqual(other.StatePtr) {
+			return false
+		}
+	}
+
+	if ((in.States != nil) && (other.States != nil)) || ((in.States == nil) != (other.States == nil)) {
+		in, other := &in.States, &other.States
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.deepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if ((in.ByName != nil) && (other.ByName != nil)) || ((in.ByName == nil) != (other.ByName == nil)) {
+		in, other := &in.ByName, &other.ByName
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !inValue.deepEqual(&otherValue) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if ((in.Names != nil) && (other.Names != nil)) || ((in.Names == nil) != (other.Names == nil)) {
+		in, other := &in.Names, &other.Names
+		if other == nil || !in.deepEqual(other) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,54 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.
+package profiles
+
+// +deepequal-gen:profile=SpecEqual,ignore=ResourceVersion
+type ObjectMeta struct {
+	Name            string
+	ResourceVersion string
+	Labels          map[string]string
+}
+
+type Spec struct {
+	Replicas *int32
+	Selector []string
+}
+
+type Status struct {
+	Ready bool
+}
+
+// +deepequal-gen:profile=SpecEqual,ignore=Status
+// +deepequal-gen:profile=IgnoreTimestamps,ignore=CreationTimestamp
+type Ttest struct {
+	ObjectMeta
+	Spec              Spec
+	Status            Status
+	CreationTimestamp int64
+	Children          []Ttest
+	Parent            *Ttest
+}
+
+type Meta struct {
+	Name            string
+	ResourceVersion string
+}
+
+// +deepequal-gen:profile=SpecEqual,ignore=Status;ResourceVersion
+type Object struct {
+	Meta
+	Spec   Spec
+	Status Status
+}
+
+// +deepequal-gen:profile=SpecEqual,ignore=Meta.ResourceVersion;Spec.Replicas
+type Dotted struct {
+	Meta
+	Spec Spec
+}
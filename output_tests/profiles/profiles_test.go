@@ -0,0 +1,123 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package profiles
+
+import (
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	replicas := int32(3)
+	x := Ttest{
+		ObjectMeta:        ObjectMeta{Name: "x", ResourceVersion: "1"},
+		Spec:              Spec{Replicas: &replicas, Selector: []string{"a"}},
+		Status:            Status{Ready: true},
+		CreationTimestamp: 1,
+		Children:          []Ttest{{ObjectMeta: ObjectMeta{Name: "child", ResourceVersion: "1"}}},
+	}
+
+	testCases := []struct {
+		name             string
+		mutate           func(y *Ttest)
+		deepEqual        bool
+		specEqual        bool
+		ignoreTimestamps bool
+	}{
+		{
+			name:             "identical",
+			mutate:           func(y *Ttest) {},
+			deepEqual:        true,
+			specEqual:        true,
+			ignoreTimestamps: true,
+		},
+		{
+			name:             "status",
+			mutate:           func(y *Ttest) { y.Status.Ready = false },
+			deepEqual:        false,
+			specEqual:        true,
+			ignoreTimestamps: false,
+		},
+		{
+			name:             "resource version",
+			mutate:           func(y *Ttest) { y.ResourceVersion = "2" },
+			deepEqual:        false,
+			specEqual:        true,
+			ignoreTimestamps: false,
+		},
+		{
+			name:             "nested resource version",
+			mutate:           func(y *Ttest) { y.Children = []Ttest{{ObjectMeta: ObjectMeta{Name: "child", ResourceVersion: "2"}}} },
+			deepEqual:        false,
+			specEqual:        true,
+			ignoreTimestamps: false,
+		},
+		{
+			name:             "timestamp",
+			mutate:           func(y *Ttest) { y.CreationTimestamp = 2 },
+			deepEqual:        false,
+			specEqual:        false,
+			ignoreTimestamps: true,
+		},
+		{
+			name:             "spec",
+			mutate:           func(y *Ttest) { y.Spec.Selector = []string{"b"} },
+			deepEqual:        false,
+			specEqual:        false,
+			ignoreTimestamps: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			y := x
+			tc.mutate(&y)
+			if r := x.DeepEqual(&y); r != tc.deepEqual {
+				t.Errorf("DeepEqual: expected %t, got %t", tc.deepEqual, r)
+			}
+			if r := x.SpecEqual(&y); r != tc.specEqual {
+				t.Errorf("SpecEqual: expected %t, got %t", tc.specEqual, r)
+			}
+			if r := x.IgnoreTimestamps(&y); r != tc.ignoreTimestamps {
+				t.Errorf("IgnoreTimestamps: expected %t, got %t", tc.ignoreTimestamps, r)
+			}
+		})
+	}
+}
+
+func TestPromotedProfileFields(t *testing.T) {
+	replicas, other := int32(3), int32(4)
+	x := Object{
+		Meta:   Meta{Name: "x", ResourceVersion: "1"},
+		Spec:   Spec{Replicas: &replicas},
+		Status: Status{Ready: true},
+	}
+
+	y := x
+	y.ResourceVersion = "2"
+	y.Status.Ready = false
+	if !x.SpecEqual(&y) {
+		t.Errorf("expected promoted ResourceVersion and Status to be ignored")
+	}
+	if x.DeepEqual(&y) {
+		t.Errorf("expected DeepEqual to compare every field")
+	}
+	y.Name = "y"
+	if x.SpecEqual(&y) {
+		t.Errorf("expected the other fields of the embedded struct to be compared")
+	}
+
+	d := Dotted{Meta: x.Meta, Spec: Spec{Replicas: &replicas, Selector: []string{"a"}}}
+	e := d
+	e.Meta.ResourceVersion = "2"
+	e.Spec.Replicas = &other
+	if !d.SpecEqual(&e) {
+		t.Errorf("expected fields named by their path to be ignored")
+	}
+	e.Spec.Selector = []string{"b"}
+	if d.SpecEqual(&e) {
+		t.Errorf("expected the other fields of nested structs to be compared")
+	}
+}
@@ -0,0 +1,392 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package profiles
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Dotted) DeepEqual(other *Dotted) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Meta != other.Meta {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// SpecEqual is an autogenerated deepequal function for the SpecEqual profile,
+// deeply comparing the receiver with other. in must be non-nil.
+// The following fields are not compared: Meta.ResourceVersion, Spec.Replicas.
+func (in *Dotted) SpecEqual(other *Dotted) bool {
+	if other == nil {
+		return false
+	}
+
+	{
+		in, other := &in.Meta, &other.Meta
+		if in.Name != other.Name {
+			return false
+		}
+	}
+
+	{
+		in, other := &in.Spec, &other.Spec
+		if ((in.Selector != nil) && (other.Selector != nil)) || ((in.Selector == nil) != (other.Selector == nil)) {
+			in, other := &in.Selector, &other.Selector
+			if other == nil {
+				return false
+			}
+
+			if len(*in) != len(*other) {
+				return false
+			} else {
+				for i, inElement := range *in {
+					if inElement != (*other)[i] {
+						return false
+					}
+				}
+			}
+		}
+
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Meta) DeepEqual(other *Meta) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.ResourceVersion != other.ResourceVersion {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Object) DeepEqual(other *Object) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Meta != other.Meta {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if in.Status != other.Status {
+		return false
+	}
+
+	return true
+}
+
+// SpecEqual is an autogenerated deepequal function for the SpecEqual profile,
+// deeply comparing the receiver with other. in must be non-nil.
+// The following fields are not compared: Status, Meta.ResourceVersion.
+func (in *Object) SpecEqual(other *Object) bool {
+	if other == nil {
+		return false
+	}
+
+	{
+		in, other := &in.Meta, &other.Meta
+		if in.Name != other.Name {
+			return false
+		}
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *ObjectMeta) DeepEqual(other *ObjectMeta) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if in.ResourceVersion != other.ResourceVersion {
+		return false
+	}
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// SpecEqual is an autogenerated deepequal function for the SpecEqual profile,
+// deeply comparing the receiver with other. in must be non-nil.
+// The following fields are not compared: ResourceVersion.
+func (in *ObjectMeta) SpecEqual(other *ObjectMeta) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+	if ((in.Labels != nil) && (other.Labels != nil)) || ((in.Labels == nil) != (other.Labels == nil)) {
+		in, other := &in.Labels, &other.Labels
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if inValue != otherValue {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Spec) DeepEqual(other *Spec) bool {
+	if other == nil {
+		return false
+	}
+
+	if (in.Replicas == nil) != (other.Replicas == nil) {
+		return false
+	} else if in.Replicas != nil {
+		if *in.Replicas != *other.Replicas {
+			return false
+		}
+	}
+
+	if ((in.Selector != nil) && (other.Selector != nil)) || ((in.Selector == nil) != (other.Selector == nil)) {
+		in, other := &in.Selector, &other.Selector
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Status) DeepEqual(other *Status) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Ready != other.Ready {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.ObjectMeta.DeepEqual(&other.ObjectMeta) {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if in.Status != other.Status {
+		return false
+	}
+
+	if in.CreationTimestamp != other.CreationTimestamp {
+		return false
+	}
+	if ((in.Children != nil) && (other.Children != nil)) || ((in.Children == nil) != (other.Children == nil)) {
+		in, other := &in.Children, &other.Children
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if (in.Parent == nil) != (other.Parent == nil) {
+		return false
+	} else if in.Parent != nil {
+		if !in.Parent.DeepEqual(other.Parent) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SpecEqual is an autogenerated deepequal function for the SpecEqual profile,
+// deeply comparing the receiver with other. in must be non-nil.
+// The following fields are not compared: Status.
+func (in *Ttest) SpecEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.ObjectMeta.SpecEqual(&other.ObjectMeta) {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if in.CreationTimestamp != other.CreationTimestamp {
+		return false
+	}
+	if ((in.Children != nil) && (other.Children != nil)) || ((in.Children == nil) != (other.Children == nil)) {
+		in, other := &in.Children, &other.Children
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.SpecEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if (in.Parent == nil) != (other.Parent == nil) {
+		return false
+	} else if in.Parent != nil {
+		if !in.Parent.SpecEqual(other.Parent) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IgnoreTimestamps is an autogenerated deepequal function for the IgnoreTimestamps profile,
+// deeply comparing the receiver with other. in must be non-nil.
+// The following fields are not compared: CreationTimestamp.
+func (in *Ttest) IgnoreTimestamps(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.ObjectMeta.DeepEqual(&other.ObjectMeta) {
+		return false
+	}
+
+	if !in.Spec.DeepEqual(&other.Spec) {
+		return false
+	}
+
+	if in.Status != other.Status {
+		return false
+	}
+
+	if ((in.Children != nil) && (other.Children != nil)) || ((in.Children == nil) != (other.Children == nil)) {
+		in, other := &in.Children, &other.Children
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.IgnoreTimestamps(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if (in.Parent == nil) != (other.Parent == nil) {
+		return false
+	} else if in.Parent != nil {
+		if !in.Parent.IgnoreTimestamps(other.Parent) {
+			return false
+		}
+	}
+
+	return true
+}
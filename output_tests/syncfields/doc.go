@@ -0,0 +1,10 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+
+// This is a test package.  Lock fields are not compared, and sync/atomic
+// fields are compared through their Load method.
+package syncfields
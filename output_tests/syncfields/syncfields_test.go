@@ -0,0 +1,85 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package syncfields
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSyncFields(t *testing.T) {
+	newTtest := func() *Ttest {
+		x := &Ttest{Name: "a", Total: &atomic.Uint64{}}
+		x.Count.Store(1)
+		x.Ready.Store(true)
+		x.Total.Store(2)
+		x.Current.Store([]string{"a"})
+		return x
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(y *Ttest)
+		equal  bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(y *Ttest) {},
+			equal:  true,
+		},
+		{
+			name: "locks",
+			mutate: func(y *Ttest) {
+				y.Lock()
+				y.mu.Lock()
+				y.once.Do(func() {})
+				y.wg = &sync.WaitGroup{}
+				y.locker = &sync.Mutex{}
+			},
+			equal: true,
+		},
+		{
+			name:   "count",
+			mutate: func(y *Ttest) { y.Count.Add(1) },
+			equal:  false,
+		},
+		{
+			name:   "ready",
+			mutate: func(y *Ttest) { y.Ready.Store(false) },
+			equal:  false,
+		},
+		{
+			name:   "total",
+			mutate: func(y *Ttest) { y.Total.Store(3) },
+			equal:  false,
+		},
+		{
+			name:   "nil total",
+			mutate: func(y *Ttest) { y.Total = nil },
+			equal:  false,
+		},
+		{
+			name:   "current",
+			mutate: func(y *Ttest) { y.Current.Store([]string{"b"}) },
+			equal:  false,
+		},
+		{
+			name:   "lease",
+			mutate: func(y *Ttest) { y.Lease.Lock() },
+			equal:  false,
+		},
+	}
+
+	x := newTtest()
+	for _, tc := range testCases {
+		y := newTtest()
+		tc.mutate(y)
+		if got := x.DeepEqual(y); got != tc.equal {
+			t.Errorf("%s: expected DeepEqual %t, got %t", tc.name, tc.equal, got)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package syncfields
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// noCopy may be embedded into structs which must not be copied after the
+// first use.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+// Lease has Lock and Unlock methods but holds data, so it is compared.
+type Lease struct {
+	Holder string
+}
+
+func (l *Lease) Lock()   { l.Holder = "locked" }
+func (l *Lease) Unlock() { l.Holder = "" }
+
+type Ttest struct {
+	sync.RWMutex
+	noCopy noCopy
+
+	mu      sync.Mutex
+	once    sync.Once
+	wg      *sync.WaitGroup
+	locker  sync.Locker
+	Count   atomic.Int64
+	Ready   atomic.Bool
+	Total   *atomic.Uint64
+	Current atomic.Value
+	Lease   Lease
+	Name    string
+}
@@ -0,0 +1,64 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package syncfields
+
+import (
+	reflect "reflect"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Lease) DeepEqual(other *Lease) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Holder != other.Holder {
+		return false
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if in.Count.Load() != other.Count.Load() {
+		return false
+	}
+
+	if in.Ready.Load() != other.Ready.Load() {
+		return false
+	}
+
+	if (in.Total == nil) != (other.Total == nil) {
+		return false
+	} else if in.Total != nil {
+		if in.Total.Load() != other.Total.Load() {
+			return false
+		}
+	}
+
+	if !reflect.DeepEqual(in.Current.Load(), other.Current.Load()) {
+		return false
+	}
+
+	if in.Lease != other.Lease {
+		return false
+	}
+
+	if in.Name != other.Name {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,7 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// This is a test package without any tags.
+package dep
@@ -0,0 +1,23 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package dep
+
+type Remote struct {
+	Nested Nested
+}
+
+type Nested struct {
+	Values map[string]int
+}
+
+// Builtins is comparable with == and needs no DeepEqual method.
+type Builtins struct {
+	Name string
+}
+
+type Unreachable struct {
+	Values []int
+}
@@ -0,0 +1,8 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// This is a test package.  Only Ttest requests generation; the types it
+// depends on are generated because the generator runs with --transitive.
+package transitive
@@ -0,0 +1,69 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package transitive
+
+import (
+	"testing"
+
+	"github.com/wind-river/deepequal-gen/output_tests/transitive/dep"
+)
+
+func TestTransitive(t *testing.T) {
+	x := Ttest{
+		Local:  Local{Names: []string{"a"}},
+		Remote: dep.Remote{Nested: dep.Nested{Values: map[string]int{"a": 1}}},
+		Slice:  []dep.Remote{{Nested: dep.Nested{Values: map[string]int{"b": 2}}}},
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(y *Ttest)
+		equal  bool
+	}{
+		{
+			name:   "identical",
+			mutate: func(y *Ttest) {},
+			equal:  true,
+		},
+		{
+			name:   "local",
+			mutate: func(y *Ttest) { y.Local.Names = []string{"b"} },
+			equal:  false,
+		},
+		{
+			name:   "remote",
+			mutate: func(y *Ttest) { y.Remote.Nested.Values = map[string]int{"a": 2} },
+			equal:  false,
+		},
+		{
+			name:   "pointer",
+			mutate: func(y *Ttest) { y.Pointer = &dep.Remote{} },
+			equal:  false,
+		},
+		{
+			name:   "slice",
+			mutate: func(y *Ttest) { y.Slice = []dep.Remote{{}} },
+			equal:  false,
+		},
+		{
+			name:   "builtins",
+			mutate: func(y *Ttest) { y.Builtins.Name = "b" },
+			equal:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		y := Ttest{
+			Local:  Local{Names: []string{"a"}},
+			Remote: dep.Remote{Nested: dep.Nested{Values: map[string]int{"a": 1}}},
+			Slice:  []dep.Remote{{Nested: dep.Nested{Values: map[string]int{"b": 2}}}},
+		}
+		tc.mutate(&y)
+		if got := x.DeepEqual(&y); got != tc.equal {
+			t.Errorf("%s: expected DeepEqual %t, got %t", tc.name, tc.equal, got)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package transitive
+
+import (
+	"github.com/wind-river/deepequal-gen/output_tests/transitive/dep"
+)
+
+// +deepequal-gen=true
+type Ttest struct {
+	Local    Local
+	Remote   dep.Remote
+	Pointer  *dep.Remote
+	Slice    []dep.Remote
+	Builtins dep.Builtins
+}
+
+type Local struct {
+	Names []string
+}
+
+// Unreachable is not referenced by Ttest and is not generated.
+type Unreachable struct {
+	Names []string
+}
@@ -0,0 +1,82 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package transitive
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Local) DeepEqual(other *Local) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Names != nil) && (other.Names != nil)) || ((in.Names == nil) != (other.Names == nil)) {
+		in, other := &in.Names, &other.Names
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if inElement != (*other)[i] {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if !in.Local.DeepEqual(&other.Local) {
+		return false
+	}
+
+	if !in.Remote.DeepEqual(&other.Remote) {
+		return false
+	}
+
+	if (in.Pointer == nil) != (other.Pointer == nil) {
+		return false
+	} else if in.Pointer != nil {
+		if !in.Pointer.DeepEqual(other.Pointer) {
+			return false
+		}
+	}
+
+	if ((in.Slice != nil) && (other.Slice != nil)) || ((in.Slice == nil) != (other.Slice == nil)) {
+		in, other := &in.Slice, &other.Slice
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !inElement.DeepEqual(&(*other)[i]) {
+					return false
+				}
+			}
+		}
+	}
+
+	if in.Builtins != other.Builtins {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,11 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+// +deepequal-gen=package
+// +deepequal-gen:ignore-unexported-fields=true
+
+// This is a test package.  Unexported fields are ignored by every type unless
+// the type overrides the package level tag.
+package unexported
@@ -0,0 +1,22 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package unexported
+
+type cache struct {
+	entries map[string]string
+}
+
+type Ttest struct {
+	cache
+	revision int
+	Name     string
+}
+
+// +deepequal-gen:ignore-unexported-fields=false
+type CompareUnexported struct {
+	revision int
+	Name     string
+}
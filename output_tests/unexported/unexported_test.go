@@ -0,0 +1,28 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package unexported
+
+import (
+	"testing"
+)
+
+func TestUnexported(t *testing.T) {
+	x := Ttest{cache: cache{entries: map[string]string{"a": "b"}}, revision: 1, Name: "a"}
+	y := Ttest{revision: 2, Name: "a"}
+	if !x.DeepEqual(&y) {
+		t.Errorf("expected unexported fields to be ignored")
+	}
+	y.Name = "b"
+	if x.DeepEqual(&y) {
+		t.Errorf("expected exported fields to be compared")
+	}
+
+	a := CompareUnexported{revision: 1, Name: "a"}
+	b := CompareUnexported{revision: 2, Name: "a"}
+	if a.DeepEqual(&b) {
+		t.Errorf("expected the type level tag to override the package level tag")
+	}
+}
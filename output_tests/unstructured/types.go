@@ -0,0 +1,26 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package unstructured
+
+type Extensions map[string]interface{}
+
+type Ttest struct {
+	Object map[string]interface{}
+	List   []interface{}
+	Value  interface{}
+	Named  Extensions
+	// +deepequal-gen:nil-equals-empty=true
+	Lenient map[string]interface{}
+	// +deepequal-gen:unordered-array=true
+	Set []interface{}
+}
+
+// +deepequal-gen:nil-equals-empty=true
+type Defaulted struct {
+	Object map[string]interface{}
+	// +deepequal-gen:nil-equals-empty=false
+	Strict map[string]interface{}
+}
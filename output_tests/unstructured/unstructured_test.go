@@ -0,0 +1,100 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+Copyright(c) 2019 Wind River Systems, Inc.
+*/
+
+package unstructured
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnstructured(t *testing.T) {
+	decoded := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(`{"replicas": 3, "ports": [80, 443], "labels": {"app": "web"}}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name  string
+		in    Ttest
+		other Ttest
+		equal bool
+	}{
+		{
+			name:  "numbers across types",
+			in:    Ttest{Object: decoded},
+			other: Ttest{Object: map[string]interface{}{"replicas": int64(3), "ports": []interface{}{80, uint16(443)}, "labels": map[string]interface{}{"app": "web"}}},
+			equal: true,
+		},
+		{
+			name:  "different numbers",
+			in:    Ttest{Object: map[string]interface{}{"replicas": 3}},
+			other: Ttest{Object: map[string]interface{}{"replicas": 3.5}},
+		},
+		{
+			name:  "numbers and strings",
+			in:    Ttest{List: []interface{}{3}},
+			other: Ttest{List: []interface{}{"3"}},
+		},
+		{
+			name:  "nested nil and empty",
+			in:    Ttest{Value: map[string]interface{}{"a": nil}},
+			other: Ttest{Value: map[string]interface{}{"a": map[string]interface{}{}}},
+		},
+		{
+			name:  "nested nil and empty in a lenient field",
+			in:    Ttest{Lenient: map[string]interface{}{"a": nil, "b": []interface{}(nil)}},
+			other: Ttest{Lenient: map[string]interface{}{"a": map[string]interface{}{}, "b": []interface{}{}}},
+			equal: true,
+		},
+		{
+			name:  "empty map and empty slice in a lenient field",
+			in:    Ttest{Lenient: map[string]interface{}{"a": map[string]interface{}{}}},
+			other: Ttest{Lenient: map[string]interface{}{"a": []interface{}{}}},
+		},
+		{
+			name:  "named container",
+			in:    Ttest{Named: Extensions{"a": []interface{}{1.0}}},
+			other: Ttest{Named: Extensions{"a": []interface{}{1}}},
+			equal: true,
+		},
+		{
+			name:  "unordered",
+			in:    Ttest{Set: []interface{}{1, "a", map[string]interface{}{"b": true}}},
+			other: Ttest{Set: []interface{}{map[string]interface{}{"b": true}, "a", 1.0}},
+			equal: true,
+		},
+		{
+			name:  "numbers outside containers",
+			in:    Ttest{Value: 1},
+			other: Ttest{Value: 1.0},
+		},
+		{
+			name:  "other values",
+			in:    Ttest{Value: struct{ A int }{1}},
+			other: Ttest{Value: struct{ A int }{2}},
+		},
+	}
+	for _, c := range cases {
+		if got := c.in.DeepEqual(&c.other); got != c.equal {
+			t.Errorf("%s: expected %v, got %v", c.name, c.equal, got)
+		}
+		if got := c.other.DeepEqual(&c.in); got != c.equal {
+			t.Errorf("%s: expected %v in reverse, got %v", c.name, c.equal, got)
+		}
+	}
+}
+
+func TestNilEqualsEmptyDefault(t *testing.T) {
+	in := Defaulted{Object: map[string]interface{}{"a": nil}}
+	other := Defaulted{Object: map[string]interface{}{"a": []interface{}{}}}
+	if !in.DeepEqual(&other) {
+		t.Errorf("expected the type tag to compare nil and empty values as equal")
+	}
+	in = Defaulted{Strict: map[string]interface{}{"a": nil}}
+	other = Defaulted{Strict: map[string]interface{}{"a": []interface{}{}}}
+	if in.DeepEqual(&other) {
+		t.Errorf("expected the field tag to override the type tag")
+	}
+}
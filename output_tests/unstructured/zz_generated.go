@@ -0,0 +1,194 @@
+// +build !ignore_autogenerated
+
+/* SPDX-License-Identifier: Apache-2.0 */
+/* Copyright(c) 2019 Wind River Systems, Inc. */
+
+// Code generated by deepequal-gen. DO NOT EDIT.
+
+package unstructured
+
+import (
+	reflect "reflect"
+
+	deepequal "github.com/wind-river/deepequal-gen/deepequal"
+)
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Defaulted) DeepEqual(other *Defaulted) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Object != nil) && (other.Object != nil)) || ((in.Object == nil) != (other.Object == nil)) {
+		in, other := &in.Object, &other.Object
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !deepequal.UnstructuredEqual(inValue, otherValue, deepequal.NilEqualsEmpty) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if ((in.Strict != nil) && (other.Strict != nil)) || ((in.Strict == nil) != (other.Strict == nil)) {
+		in, other := &in.Strict, &other.Strict
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !deepequal.UnstructuredEqual(inValue, otherValue, deepequal.NilDiffersFromEmpty) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Extensions) DeepEqual(other *Extensions) bool {
+	if other == nil {
+		return false
+	}
+
+	if len(*in) != len(*other) {
+		return false
+	} else {
+		for key, inValue := range *in {
+			if otherValue, present := (*other)[key]; !present {
+				return false
+			} else {
+				if !deepequal.UnstructuredEqual(inValue, otherValue, deepequal.NilDiffersFromEmpty) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// DeepEqual is an autogenerated deepequal function, deeply comparing the
+// receiver with other. in must be non-nil.
+func (in *Ttest) DeepEqual(other *Ttest) bool {
+	if other == nil {
+		return false
+	}
+
+	if ((in.Object != nil) && (other.Object != nil)) || ((in.Object == nil) != (other.Object == nil)) {
+		in, other := &in.Object, &other.Object
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !deepequal.UnstructuredEqual(inValue, otherValue, deepequal.NilDiffersFromEmpty) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if ((in.List != nil) && (other.List != nil)) || ((in.List == nil) != (other.List == nil)) {
+		in, other := &in.List, &other.List
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for i, inElement := range *in {
+				if !deepequal.UnstructuredEqual(inElement, (*other)[i], deepequal.NilDiffersFromEmpty) {
+					return false
+				}
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(in.Value, other.Value) {
+		return false
+	}
+
+	if ((in.Named != nil) && (other.Named != nil)) || ((in.Named == nil) != (other.Named == nil)) {
+		in, other := &in.Named, &other.Named
+		if other == nil || !in.DeepEqual(other) {
+			return false
+		}
+	}
+
+	if ((in.Lenient != nil) && (other.Lenient != nil)) || ((in.Lenient == nil) != (other.Lenient == nil)) {
+		in, other := &in.Lenient, &other.Lenient
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for key, inValue := range *in {
+				if otherValue, present := (*other)[key]; !present {
+					return false
+				} else {
+					if !deepequal.UnstructuredEqual(inValue, otherValue, deepequal.NilEqualsEmpty) {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	if ((in.Set != nil) && (other.Set != nil)) || ((in.Set == nil) != (other.Set == nil)) {
+		in, other := &in.Set, &other.Set
+		if other == nil {
+			return false
+		}
+
+		if len(*in) != len(*other) {
+			return false
+		} else {
+			for _, inElement := range *in {
+				found := false
+				for _, otherElement := range *other {
+					if deepequal.UnstructuredEqual(inElement, otherElement, deepequal.NilDiffersFromEmpty) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}